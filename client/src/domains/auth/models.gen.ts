@@ -0,0 +1,23 @@
+// Code generated by cmd/gen-ts. DO NOT EDIT.
+
+export interface LoginRequest {
+    email: string;
+    password: string;
+    remember_me: boolean;
+}
+
+export interface RegisterRequest {
+    display_name: string;
+    email: string;
+    password: string;
+    password_confirmation: string;
+}
+
+export interface MeResponse {
+    id: number;
+    email: string;
+    display_name: string;
+    email_confirmed?: string;
+    role: string;
+    permissions: string[];
+}
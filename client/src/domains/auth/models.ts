@@ -1,3 +1,6 @@
+export type { LoginRequest, RegisterRequest, MeResponse } from "./models.gen.ts";
+
+// Shared by the login and signup forms; the API types are generated by cmd/gen-ts
 export interface AuthForm {
     display_name?: string;
     email: string;
@@ -0,0 +1,35 @@
+// Code generated by cmd/gen-ts. DO NOT EDIT.
+
+export interface Invitation {
+    id: number;
+    email: string;
+    role_id: number;
+    organization_id?: number;
+    invited_by?: number;
+    status: string;
+    expires_at: string;
+    accepted_at?: string;
+    revoked_at?: string;
+    created_at: string;
+}
+
+export interface CreateInvitationRequest {
+    email: string;
+    role_id: number;
+}
+
+export interface CreateInvitationResponse {
+    invitation: Invitation | null;
+    token: string;
+}
+
+export interface AcceptInvitationRequest {
+    token: string;
+    display_name: string;
+    password: string;
+    password_confirmation: string;
+}
+
+export interface JoinOrganizationRequest {
+    token: string;
+}
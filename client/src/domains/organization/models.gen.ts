@@ -0,0 +1,27 @@
+// Code generated by cmd/gen-ts. DO NOT EDIT.
+
+export interface Organization {
+    id: number;
+    name: string;
+    slug: string;
+    role_id?: number;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface Member {
+    user_id: number;
+    email: string;
+    display_name: string;
+    role_id: number;
+    joined_at: string;
+}
+
+export interface CreateOrganizationRequest {
+    name: string;
+    slug: string;
+}
+
+export interface UpdateMemberRoleRequest {
+    role_id: number;
+}
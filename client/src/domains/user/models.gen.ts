@@ -0,0 +1,37 @@
+// Code generated by cmd/gen-ts. DO NOT EDIT.
+
+export interface User {
+    id: number;
+    email: string;
+    display_name: string;
+    email_confirmed?: string;
+    role_id: number;
+    role?: Role;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface Role {
+    id: number;
+    name: string;
+    is_default: boolean;
+    description?: string;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface Permission {
+    id: number;
+    name: string;
+    description: string;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface PaginatedUsers {
+    data: User[];
+    page: number;
+    page_size: number;
+    total: number;
+    total_pages: number;
+}
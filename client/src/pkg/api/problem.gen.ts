@@ -0,0 +1,22 @@
+// Code generated by cmd/gen-ts. DO NOT EDIT.
+
+export interface ProblemDetails {
+    type: string;
+    title: string;
+    status: number;
+    detail?: string;
+    instance?: string;
+    code: string;
+    request_id?: string;
+    errors?: FieldError[];
+}
+
+export interface FieldError {
+    field: string;
+    message: string;
+}
+
+export interface SuccessResponse {
+    title?: string;
+    message?: string;
+}
@@ -1,23 +1,22 @@
 import { defineStore } from "pinia";
 import apiClient from "../api/axios.ts";
 import { useThemeStore } from "./theme_store.ts";
-import type { User } from "../../domains/user/models.ts";
-import type { AuthForm } from "../../domains/auth/models.ts";
+import type { AuthForm, MeResponse } from "../../domains/auth/models.ts";
 import router from "../router/router.ts";
 
 export const useAuthStore = defineStore("auth", {
     state: () => ({
         apiPrefix: "auth",
         authenticated: localStorage.getItem("authenticated") == "true",
-        user: null as User | null,
+        user: null as MeResponse | null,
         initialized: false,
     }),
     getters: {
         isAuthenticated: (s) => s.authenticated,
         isInitialized: (s) => s.initialized,
         isValidated: (s) => !!s.user?.email_confirmed,
-        isAdmin: (s) => s.user?.role?.name == "super-admin" || s.user?.role?.name == "admin",
-        isSuperAdmin: (s) => s.user?.role?.name == "super-admin",
+        isAdmin: (s) => s.user?.role == "super-admin" || s.user?.role == "admin",
+        isSuperAdmin: (s) => s.user?.role == "super-admin",
     },
     actions: {
         async login(form: AuthForm) {
@@ -48,7 +47,7 @@ export const useAuthStore = defineStore("auth", {
         },
 
         async getAuthUser(set = true) {
-            const response = await apiClient.get(`${this.apiPrefix}/me`, {
+            const response = await apiClient.get<MeResponse>(`${this.apiPrefix}/me`, {
                 params: { withSecrets: true },
             });
 
@@ -63,7 +62,7 @@ export const useAuthStore = defineStore("auth", {
             return response.data;
         },
 
-        setUser(userData: User) {
+        setUser(userData: MeResponse) {
             this.user = userData;
         },
 
@@ -91,7 +90,7 @@ export const useAuthStore = defineStore("auth", {
             localStorage.setItem("authenticated", status.toString());
         },
 
-        setInitialized(user: User | null) {
+        setInitialized(user: MeResponse | null) {
             this.initialized = user !== null;
         },
 
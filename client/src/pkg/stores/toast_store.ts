@@ -1,6 +1,7 @@
 import { defineStore } from "pinia";
 import { useToast } from "primevue/usetoast";
 import axios from "axios";
+import type { ProblemDetails } from "../api/problem.gen.ts";
 
 export const useToastStore = defineStore("toast", () => {
     const toast = useToast();
@@ -14,11 +15,15 @@ export const useToastStore = defineStore("toast", () => {
         let detail = "An unknown error occurred.";
 
         if (axios.isAxiosError(error)) {
-            const data = error.response?.data as { title?: string; message?: string } | undefined;
+            // Errors are RFC 9457 problem documents
+            const data = error.response?.data as Partial<ProblemDetails> | undefined;
 
-            if (data?.title || data?.message) {
+            if (data?.title || data?.detail) {
                 summary = data.title ?? "Error";
-                detail = data.message ?? "Something went wrong.";
+                detail = data.detail ?? "Something went wrong.";
+                if (data.errors?.length) {
+                    detail += "\n" + data.errors.map((e) => `${e.field}: ${e.message}`).join("\n");
+                }
             }
 
             if (!error.response || error.code === "ERR_NETWORK" || error.message === "Network Error") {
@@ -27,7 +32,7 @@ export const useToastStore = defineStore("toast", () => {
             }
 
             if (
-                (!data?.message || detail === "Something went wrong.") &&
+                (!data?.detail || detail === "Something went wrong.") &&
                 error.message &&
                 error.message !== "Request failed with status code 500"
             ) {
@@ -4,14 +4,14 @@ export function usePermissions() {
     const auth = useAuthStore();
 
     const hasRole = (role: string) => {
-        const name = auth.user?.role?.name;
+        const name = auth.user?.role;
         return name === "super-admin" || name === role;
     };
 
     const hasPermission = (perms: string | string[]) => {
         const need = Array.isArray(perms) ? perms : [perms];
-        const granted = auth.user?.role?.permissions?.map((p) => p.name) ?? [];
-        if (granted.includes("root_access")) return true;
+        const granted = auth.user?.permissions ?? [];
+        if (granted.includes("system:superadmin")) return true;
         return need.every((n) => granted.includes(n));
     };
 
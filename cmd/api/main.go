@@ -4,19 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/domains/audit"
 	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
 	"go-web-template/internal/domains/user"
+	"go-web-template/internal/health"
+	"go-web-template/internal/metrics"
+	"go-web-template/internal/migrate"
+	"go-web-template/internal/server"
+	"go-web-template/internal/tracing"
+	"go-web-template/migrations"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	mWare "go-web-template/internal/middleware"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
+	"github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/joho/godotenv/autoload"
 	"go.uber.org/zap"
 
@@ -26,44 +37,118 @@ import (
 	"go-web-template/pkg/logging"
 )
 
-type Handlers struct {
-	Auth *auth.AuthHandler
-	User *user.UserHandler
-}
-
 func main() {
 
-	if err := config.Load(); err != nil {
-		panic("failed to load config: " + err.Error())
+	args, err := config.LoadArgs(os.Args[1:])
+	if err != nil {
+		// Lists every invalid setting, one per line
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		os.Exit(1)
 	}
 	cfg := config.Get()
 
-	logger := logging.InitLogger(cfg.App.Environment == "production", cfg.App.LogLevel)
+	switch {
+	case len(args) == 2 && args[0] == "config" && args[1] == "print":
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to print config:", err)
+			os.Exit(1)
+		}
+		return
+	case len(args) > 0:
+		fmt.Fprintln(os.Stderr, "usage: api [flags] [config print]")
+		os.Exit(2)
+	}
+
+	// The level, CORS origins and registration switch follow configuration reloads
+	logLevel := zap.NewAtomicLevelAt(logging.ParseLevel(cfg.App.LogLevel))
+	logger := logging.NewLogger(cfg.App.Environment == "production", logLevel)
 	defer func() {
 		_ = logger.Sync()
 	}()
 	logger = logger.Named("api")
+	// Code without a request-scoped logger logs through the global logger
+	zap.ReplaceGlobals(logger)
 
 	logger.Info("starting application",
 		zap.String("environment", cfg.App.Environment),
 		zap.String("port", cfg.Server.Port),
 	)
 
-	db, err := store.NewDB(cfg.Database)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.App.Environment)
 	if err != nil {
-		logger.Fatal("failed to connect to database", zap.Error(err))
+		logger.Fatal("failed to set up tracing", zap.Error(err))
 	}
-	defer func(db *sql.DB) {
-		err := db.Close()
-		if err != nil {
-			logger.Fatal("failed to close database connection", zap.Error(err))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("failed to flush traces", zap.Error(err))
 		}
-	}(db)
+	}()
+
+	// Handlers render driver errors, e.g. a unique violation as 409 Conflict
+	apperr.RegisterClassifier(store.ClassifyError)
+
+	// Migrations run as DB_USER, which owns the schema, and before the serving pool
+	// switches to DB_APP_ROLE, which they create
+	migrationPool, err := store.NewMigrationPool(context.Background(), cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer migrationPool.Close()
+	// goose runs on database/sql
+	sqlDB := stdlib.OpenDBFromPool(migrationPool)
+	if err := autoMigrate(context.Background(), sqlDB, cfg.Database.AutoMigrate, logger); err != nil {
+		logger.Fatal("failed to migrate database", zap.Error(err))
+	}
+
+	pool, err := store.NewPool(context.Background(), cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer pool.Close()
 	logger.Info("database connected")
 
+	// The tenant policies only isolate organizations from roles they apply to
+	if cfg.Database.RowLevelSecurity {
+		if err := store.CheckRowLevelSecurity(context.Background(), pool); err != nil {
+			logger.Fatal("refusing to serve without tenant isolation", zap.Error(err))
+		}
+	}
+
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(pool, cfg.Database.DBName)
+
+	replicas, err := store.OpenReplicas(context.Background(), cfg.Database)
+	if err != nil {
+		logger.Fatal("failed to open database replicas", zap.Error(err))
+	}
+	defer func() {
+		for _, replica := range replicas {
+			replica.Close()
+		}
+	}()
+	// Read-only queries go to the replicas, if any; writes and transactions to the primary
+	dbRouter := store.NewRouter(pool, replicas)
+	// Every query is timed, and logged when slower than DB_SLOW_QUERY_THRESHOLD
+	queryStats := store.NewQueryStats()
+	db := store.NewInstrumentedDB(dbRouter, cfg.Database, appMetrics, queryStats)
+
 	// Create SQLC queries instance
 	queries := database.New(db)
 
+	// Readiness checks, cached briefly so probes don't add load to the database
+	checks := health.NewRegistry(2*time.Second, time.Second)
+	checks.Register("database", health.DBPing(pool))
+	migrationsCheck, err := health.Migrations(sqlDB, migrations.FS, migrations.Go()...)
+	if err != nil {
+		logger.Fatal("failed to set up migrations check", zap.Error(err))
+	}
+	checks.Register("migrations", migrationsCheck)
+	if cfg.Mail.Host != "" {
+		checks.Register("mail", health.Dial(net.JoinHostPort(cfg.Mail.Host, cfg.Mail.Port)))
+	}
+
 	// Initialize auth middleware
 	authMiddleware := mWare.NewAuthMiddleware(
 		cfg,
@@ -73,73 +158,135 @@ func main() {
 		cfg.Auth.RefreshTTLLong,
 	)
 
+	txManager, err := store.NewTxManager(db, cfg.Database)
+	if err != nil {
+		logger.Fatal("failed to create transaction manager", zap.Error(err))
+	}
+
 	// Initialize services
+	auditService := audit.NewAuditService(queries)
 	userService := user.NewUserService(queries)
-	authService := auth.NewAuthService(queries)
+	authService := auth.NewAuthService(queries, txManager, auditService)
+	invitationService := invitation.NewInvitationService(queries, txManager, authService, auditService, cfg.Auth.InviteSecret, cfg.Auth.InviteTTL)
+	organizationService := organization.NewOrganizationService(queries, txManager, auditService)
 	// Add more services as needed
 
+	permissionMiddleware := mWare.NewPermissionMiddleware(userService, organizationService, logger)
+	tenantMiddleware := mWare.NewTenantMiddleware(organizationService, logger)
+
 	// Initialize handlers
 	userHandler := user.NewUserHandler(userService)
-	authHandler := auth.NewAuthHandler(authService, authMiddleware)
+	authHandler := auth.NewAuthHandler(authService, authMiddleware, appMetrics, cfg.Auth.RegistrationEnabled)
+	invitationHandler := invitation.NewInvitationHandler(invitationService, authService, authMiddleware, permissionMiddleware)
+	organizationHandler := organization.NewOrganizationHandler(organizationService, invitationService, tenantMiddleware, permissionMiddleware)
+	auditHandler := audit.NewAuditHandler(auditService, permissionMiddleware)
 	// Add more handlers as needed
 
-	h := Handlers{
-		Auth: authHandler,
-		User: userHandler,
+	corsMiddleware := mWare.NewCORS(cfg.Server.AllowedOrigins)
+	config.Subscribe(func(c *config.Config) {
+		logLevel.SetLevel(logging.ParseLevel(c.App.LogLevel))
+		corsMiddleware.SetAllowedOrigins(c.Server.AllowedOrigins)
+		authHandler.SetRegistrationEnabled(c.Auth.RegistrationEnabled)
+	})
+
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go watchConfig(watchCtx, cfg.App.ConfigWatchInterval, logger)
+	go dbRouter.Watch(watchCtx, cfg.Database.ReplicaCheckInterval)
+
+	h := server.Handlers{
+		Auth:         authHandler,
+		User:         userHandler,
+		Invitation:   invitationHandler,
+		Organization: organizationHandler,
+		Audit:        auditHandler,
+		Health:       checks,
+		CORS:         corsMiddleware,
 	}
 
-	r := setupRouter(cfg, &h, authMiddleware, logger)
+	r := server.NewRouter(cfg, &h, authMiddleware, appMetrics, logger)
 
-	startServer(cfg, r, logger)
+	admin := server.NewAdminRouter(appMetrics, queryStats, checks)
+
+	startServer(cfg, r, admin, checks, logger)
 }
 
-func setupRouter(cfg *config.Config, h *Handlers, authMiddleware *mWare.AuthMiddleware, logger *zap.Logger) *chi.Mux {
-	r := chi.NewRouter()
-
-	// Middleware
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
-
-	// CORS
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   cfg.Server.AllowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
-
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, err := w.Write([]byte("OK"))
-		if err != nil {
-			logger.Fatal("failed to write health response", zap.Error(err))
+// watchConfig reloads the configuration on SIGHUP and, with a positive interval, when
+// the config files change, until ctx is done.
+func watchConfig(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	changed := make(chan struct{}, 1)
+	if interval > 0 {
+		go config.Watch(ctx, interval, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			reloadConfig("SIGHUP", logger)
+		case <-changed:
+			reloadConfig("file change", logger)
 		}
-	})
+	}
+}
 
-	// API routes
-	r.Route("/api", func(r chi.Router) {
-		// Public routes
-		r.Mount("/auth", h.Auth.Routes())
+func reloadConfig(trigger string, logger *zap.Logger) {
+	result, err := config.Reload()
+	if err != nil {
+		logger.Error("configuration reload rejected", zap.String("trigger", trigger), zap.Error(err))
+		return
+	}
 
-		// Protected routes
-		r.Group(func(r chi.Router) {
-			r.Use(authMiddleware.WebClientAuthentication)
+	if len(result.Ignored) > 0 {
+		logger.Warn("configuration changes need a restart", zap.Strings("keys", result.Ignored))
+	}
+	logger.Info("configuration reloaded", zap.String("trigger", trigger), zap.Strings("applied", result.Applied))
+}
 
-			r.Mount("/users", h.User.Routes())
-			// Mount more protected handlers as needed
-		})
-	})
+// autoMigrate applies the pending embedded migrations when mode is "up", holding the
+// migration lock so replicas starting together apply them once, and when mode is
+// "check" fails if any are pending.
+func autoMigrate(ctx context.Context, db *sql.DB, mode string, logger *zap.Logger) error {
+	if mode == "" || mode == "off" {
+		return nil
+	}
+
+	migrator, err := migrate.New(db)
+	if err != nil {
+		return err
+	}
 
-	logger.Info("router configured")
-	return r
+	if mode == "check" {
+		if err := migrator.Check(ctx); err != nil {
+			return fmt.Errorf("%w; run migrate up or set AUTO_MIGRATE=up", err)
+		}
+		return nil
+	}
+
+	results, err := migrator.Up(ctx)
+	for _, result := range results {
+		logger.Info("migration applied",
+			zap.String("migration", filepath.Base(result.Source.Path)),
+			zap.Duration("duration", result.Duration),
+		)
+	}
+	return err
 }
 
-func startServer(cfg *config.Config, handler http.Handler, logger *zap.Logger) {
+// startServer serves handler, and admin on the admin listener, until
+// SIGINT or SIGTERM. Readiness fails for the configured shutdown delay before the
+// servers stop accepting connections.
+func startServer(cfg *config.Config, handler, admin http.Handler, checks *health.Registry, logger *zap.Logger) {
 	srv := &http.Server{
 		Addr:         cfg.Server.Host + ":" + cfg.Server.Port,
 		Handler:      handler,
@@ -148,14 +295,22 @@ func startServer(cfg *config.Config, handler http.Handler, logger *zap.Logger) {
 		IdleTimeout:  120 * time.Second,
 	}
 
-	go func() {
-		logger.Info("server starting",
-			zap.String("address", srv.Addr),
-		)
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logger.Fatal("server failed to start", zap.Error(err))
-		}
-	}()
+	servers := []*http.Server{srv, {
+		Addr:              cfg.Server.AdminHost + ":" + cfg.Server.AdminPort,
+		Handler:           admin,
+		ReadHeaderTimeout: 10 * time.Second,
+	}}
+
+	for _, s := range servers {
+		go func(s *http.Server) {
+			logger.Info("server starting",
+				zap.String("address", s.Addr),
+			)
+			if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Fatal("server failed to start", zap.Error(err))
+			}
+		}(s)
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -163,11 +318,19 @@ func startServer(cfg *config.Config, handler http.Handler, logger *zap.Logger) {
 
 	logger.Info("shutting down server...")
 
+	checks.ShutDown()
+	if cfg.Server.ShutdownDelay > 0 {
+		logger.Info("draining before shutdown", zap.Duration("delay", cfg.Server.ShutdownDelay))
+		time.Sleep(cfg.Server.ShutdownDelay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		logger.Fatal("server forced to shutdown", zap.Error(err))
+	for _, s := range servers {
+		if err := s.Shutdown(ctx); err != nil {
+			logger.Fatal("server forced to shutdown", zap.Error(err))
+		}
 	}
 
 	logger.Info("server stopped gracefully")
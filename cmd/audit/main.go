@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-web-template/internal/domains/audit"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"go-web-template/internal/config"
+	"go-web-template/internal/database"
+	"go-web-template/internal/store"
+	"go-web-template/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// Usage: audit purge [days]
+// Deletes audit events older than days, defaulting to AUDIT_RETENTION_DAYS.
+func main() {
+
+	args, err := config.LoadArgs(os.Args[1:])
+	if err != nil {
+		// Lists every invalid setting, one per line
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		os.Exit(1)
+	}
+	cfg := config.Get()
+
+	logger := logging.InitLogger(cfg.App.Environment == "production", cfg.App.LogLevel)
+	defer func(log *zap.Logger) {
+		_ = log.Sync() // Ignore sync errors
+	}(logger)
+	logger = logger.Named("audit")
+
+	if len(args) == 0 || args[0] != "purge" {
+		logger.Fatal("usage: audit purge [days]")
+	}
+
+	retentionDays := cfg.Audit.RetentionDays
+	if len(args) > 1 {
+		days, err := strconv.Atoi(args[1])
+		if err != nil || days < 1 {
+			logger.Fatal("invalid retention days", zap.String("days", args[1]))
+		}
+		retentionDays = days
+	}
+
+	pool, err := store.NewPool(context.Background(), cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	logger.Info("purging audit events", zap.Time("before", cutoff))
+
+	deleted, err := audit.NewAuditService(database.New(pool)).PurgeBefore(ctx, cutoff)
+	if err != nil {
+		logger.Fatal("purge failed", zap.Error(err))
+	}
+
+	logger.Info("purge completed", zap.Int64("deleted", deleted))
+}
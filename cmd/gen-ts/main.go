@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/tsgen"
+	"go-web-template/internal/utils"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// files maps each generated TypeScript module to the Go types it declares. Paths are
+// relative to the repository root.
+var files = []tsgen.File{
+	{
+		Path:  "client/src/pkg/api/problem.gen.ts",
+		Types: []any{utils.ProblemDetails{}, apperr.FieldError{}, utils.SuccessResponse{}},
+	},
+	{
+		Path:  "client/src/domains/auth/models.gen.ts",
+		Types: []any{auth.LoginRequest{}, auth.RegisterRequest{}, auth.MeResponse{}},
+	},
+	{
+		Path:  "client/src/domains/user/models.gen.ts",
+		Types: []any{user.User{}, user.Role{}, user.Permission{}, user.PaginatedUsers{}},
+	},
+	{
+		Path: "client/src/domains/invitation/models.gen.ts",
+		Types: []any{
+			invitation.Invitation{},
+			invitation.CreateInvitationRequest{},
+			invitation.CreateInvitationResponse{},
+			invitation.AcceptInvitationRequest{},
+			invitation.JoinOrganizationRequest{},
+		},
+	},
+	{
+		Path: "client/src/domains/organization/models.gen.ts",
+		Types: []any{
+			organization.Organization{},
+			organization.Member{},
+			organization.CreateOrganizationRequest{},
+			organization.UpdateMemberRoleRequest{},
+		},
+	},
+	{
+		Path:  "client/src/domains/audit/models.gen.ts",
+		Types: []any{audit.AuditEvent{}, audit.PaginatedEvents{}},
+	},
+}
+
+// Usage: gen-ts [-check] [-root dir]
+// Writes TypeScript interfaces for the API types into the client. With -check, nothing
+// is written and the command fails if any generated file is missing or stale.
+func main() {
+	check := flag.Bool("check", false, "report stale files instead of writing them")
+	root := flag.String("root", ".", "repository root")
+	flag.Parse()
+
+	stale, err := run(*root, *check)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen-ts:", err)
+		os.Exit(1)
+	}
+
+	if *check && len(stale) > 0 {
+		for _, path := range stale {
+			fmt.Fprintln(os.Stderr, "gen-ts: stale", path)
+		}
+		fmt.Fprintln(os.Stderr, "gen-ts: run `make gen-ts` to update the client types")
+		os.Exit(1)
+	}
+}
+
+// run generates the client types under root and returns the files whose contents
+// changed. They are only written when check is false.
+func run(root string, check bool) ([]string, error) {
+	generated, err := tsgen.Generate(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for path, content := range generated {
+		target := filepath.Join(root, filepath.FromSlash(path))
+
+		current, err := os.ReadFile(target)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if bytes.Equal(current, content) {
+			continue
+		}
+		stale = append(stale, path)
+
+		if check {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(stale)
+	return stale, nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The checked-in client types must match the Go models; run `make gen-ts` after changing them.
+func TestClientTypesAreCurrent(t *testing.T) {
+	stale, err := run("../..", true)
+	require.NoError(t, err)
+	assert.Empty(t, stale, "client types are stale, run `make gen-ts`")
+}
@@ -1,23 +1,56 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"go-web-template/internal/migrate"
+	"go-web-template/migrations"
 	"go-web-template/pkg/logging"
-	"log"
 	"os"
+	"strconv"
+	"text/tabwriter"
+	"text/template"
 
+	"github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/joho/godotenv/autoload"
-	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
 	"go.uber.org/zap"
 
 	"go-web-template/internal/config"
+	"go-web-template/internal/store"
 )
 
+const usage = `usage: migrate [config flags] <command>
+
+Applies the migrations embedded in the binary; the schema-changing commands hold an
+advisory lock, so concurrent runs wait for each other.
+
+  up                   apply all pending migrations
+  up-to VERSION        apply pending migrations up to and including VERSION
+  down                 roll back the latest migration
+  down-to VERSION      roll back the migrations newer than VERSION (0 for all)
+  redo                 roll back the latest migration and apply it again
+  reset [-force]       roll back all migrations; -force is required in production
+  status [-json]       list applied and pending migrations
+  version [-json]      print the database's migration version
+
+Commands on the migration files in the source tree:
+
+  create [-dir DIR] NAME [sql|go]
+                           create DIR/<timestamp>_NAME.sql, or a Go migration for data
+                           changes SQL can't express (DIR defaults to migrations)
+  fix [-dir DIR]           renumber timestamped migrations sequentially
+  validate                 check the embedded migrations without running them`
+
 func main() {
-	if err := config.Load(); err != nil {
-		panic("failed to load config: " + err.Error())
+	args, err := config.LoadArgs(os.Args[1:])
+	if err != nil {
+		// Lists every invalid setting, one per line
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		os.Exit(1)
 	}
 	cfg := config.Get()
 
@@ -27,19 +60,75 @@ func main() {
 	}(logger)
 	logger = logger.Named("migrate")
 
-	dbURL := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.DBName,
-	)
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	dir := flags.String("dir", "migrations", "migrations directory of the source tree")
+	force := flags.Bool("force", false, "reset in production")
+	asJSON := flags.Bool("json", false, "print JSON")
+	_ = flags.Parse(args[1:])
+	commandArgs := flags.Args()
+
+	// These work on files and don't need a database
+	switch command {
+	case "create":
+		if len(commandArgs) == 0 {
+			logger.Fatal("migration name required: go run cmd/migrate/main.go create <name> [sql|go]")
+		}
+		migrationType := "sql"
+		if len(commandArgs) > 1 {
+			migrationType = commandArgs[1]
+		}
+		var err error
+		switch migrationType {
+		case "sql":
+			err = goose.Create(nil, *dir, commandArgs[0], "sql")
+		case "go":
+			err = goose.CreateWithTemplate(nil, *dir, goMigrationTemplate, commandArgs[0], "go")
+		default:
+			logger.Fatal("migration type must be sql or go", zap.String("type", migrationType))
+		}
+		if err != nil {
+			logger.Fatal("migration create failed:", zap.Error(err))
+		}
+		fmt.Printf("Created migration: %s\n", commandArgs[0])
+		return
 
-	db, err := sql.Open("postgres", dbURL)
+	case "fix":
+		if err := goose.Fix(*dir); err != nil {
+			logger.Fatal("migration fix failed", zap.Error(err))
+		}
+		return
+
+	case "validate":
+		if err := migrate.Validate(migrations.FS, migrations.Go()...); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("Migrations are valid")
+		return
+
+	case "up", "up-to", "down", "down-to", "redo", "reset", "status", "version":
+	default:
+		logger.Error("migration command not recognized", zap.String("command", command))
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// Go migrations log their progress through the context
+	ctx := logging.WithContext(context.Background(), logger)
+	pool, err := store.NewMigrationPool(ctx, cfg.Database, logger)
 	if err != nil {
-		logger.Fatal("failed to open database", zap.Error(err))
+		logger.Fatal("failed to connect to database", zap.Error(err))
 	}
+	defer pool.Close()
+
+	// goose runs on database/sql
+	db := stdlib.OpenDBFromPool(pool)
 	defer func(db *sql.DB) {
 		err := db.Close()
 		if err != nil {
@@ -47,57 +136,133 @@ func main() {
 		}
 	}(db)
 
-	if err := db.Ping(); err != nil {
-		log.Fatal("failed to ping database:", err)
-	}
-
-	if err := goose.SetDialect("postgres"); err != nil {
-		logger.Fatal("failed to set dialect:", zap.Error(err))
-	}
-
-	args := os.Args[1:]
-	if len(args) == 0 {
-		os.Exit(1)
+	migrator, err := migrate.New(db)
+	if err != nil {
+		logger.Fatal("failed to load migrations", zap.Error(err))
 	}
 
-	command := args[0]
-	commandArgs := args[1:]
-
 	switch command {
 	case "up":
-		if err := goose.Up(db, "migrations"); err != nil {
+		results, err := migrator.Up(ctx)
+		printResults(results)
+		if err != nil {
 			logger.Fatal("migration up failed:", zap.Error(err))
 		}
 		fmt.Println("Migrations applied successfully")
 
+	case "up-to":
+		results, err := migrator.UpTo(ctx, versionArg(commandArgs, logger))
+		printResults(results)
+		if err != nil {
+			logger.Fatal("migration up-to failed", zap.Error(err))
+		}
+
 	case "down":
-		if err := goose.Down(db, "migrations"); err != nil {
+		result, err := migrator.Down(ctx)
+		if err != nil {
 			logger.Fatal("migration down failed:", zap.Error(err))
 		}
+		printResults([]*goose.MigrationResult{result})
 		fmt.Println("Migration rolled back")
 
-	case "status":
-		if err := goose.Status(db, "migrations"); err != nil {
-			logger.Fatal("migration status failed:", zap.Error(err))
+	case "down-to":
+		results, err := migrator.DownTo(ctx, versionArg(commandArgs, logger))
+		printResults(results)
+		if err != nil {
+			logger.Fatal("migration down-to failed", zap.Error(err))
 		}
 
-	case "create":
-		if len(commandArgs) == 0 {
-			logger.Fatal("migration name required: go run cmd/migrate/main.go create <name>")
-		}
-		if err := goose.Create(db, "migrations", commandArgs[0], "sql"); err != nil {
-			logger.Fatal("migration create failed:", zap.Error(err))
+	case "redo":
+		results, err := migrator.Redo(ctx)
+		printResults(results)
+		if err != nil {
+			logger.Fatal("migration redo failed", zap.Error(err))
 		}
-		fmt.Printf("Created migration: %s\n", commandArgs[0])
 
 	case "reset":
-		if err := goose.Reset(db, "migrations"); err != nil {
+		if cfg.App.Environment == "production" && !*force {
+			logger.Fatal("refusing to reset a production database; pass -force to drop everything")
+		}
+		results, err := migrator.DownTo(ctx, 0)
+		printResults(results)
+		if err != nil {
 			logger.Fatal("migration reset failed:", zap.Error(err))
 		}
 		fmt.Println("All migrations rolled back")
 
-	default:
-		logger.Error("migration command not recognized", zap.String("command", command))
-		os.Exit(1)
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			logger.Fatal("migration status failed:", zap.Error(err))
+		}
+		if *asJSON {
+			printJSON(status)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Applied At\tMigration")
+		for _, m := range status.Migrations {
+			appliedAt := "Pending"
+			if m.AppliedAt != nil {
+				appliedAt = m.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\n", appliedAt, m.Name)
+		}
+		_ = w.Flush()
+
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			logger.Fatal("migration version failed", zap.Error(err))
+		}
+		if *asJSON {
+			printJSON(map[string]int64{"version": version})
+			return
+		}
+		fmt.Println(version)
 	}
 }
+
+// goMigrationTemplate is the skeleton of a Go migration, registered with the migrations
+// package when the binaries are built.
+var goMigrationTemplate = template.Must(template.New("go-migration").Parse(`package migrations
+
+import "context"
+
+func init() {
+	register(up{{.CamelName}}, down{{.CamelName}})
+}
+
+// up{{.CamelName}} runs in the migration's transaction; use tx.Queries for the sqlc
+// queries and tx.EachBatch to work through large tables.
+func up{{.CamelName}}(ctx context.Context, tx *Tx) error {
+	return nil
+}
+
+func down{{.CamelName}}(ctx context.Context, tx *Tx) error {
+	return nil
+}
+`))
+
+func versionArg(args []string, logger *zap.Logger) int64 {
+	if len(args) != 1 {
+		logger.Fatal("a migration version is required, e.g. 20261018120000")
+	}
+	version, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || version < 0 {
+		logger.Fatal("invalid migration version", zap.String("version", args[0]))
+	}
+	return version
+}
+
+func printResults(results []*goose.MigrationResult) {
+	for _, result := range results {
+		fmt.Println(result)
+	}
+}
+
+func printJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
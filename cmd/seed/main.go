@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 	"go-web-template/internal/store/seeders"
 	"os"
 	"time"
@@ -18,8 +18,11 @@ import (
 
 func main() {
 
-	if err := config.Load(); err != nil {
-		panic("failed to load config: " + err.Error())
+	args, err := config.LoadArgs(os.Args[1:])
+	if err != nil {
+		// Lists every invalid setting, one per line
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		os.Exit(1)
 	}
 	cfg := config.Get()
 
@@ -30,7 +33,6 @@ func main() {
 	logger = logger.Named("seeder")
 
 	// Get seed type from args
-	args := os.Args[1:]
 	seedType := "help"
 	if len(args) > 0 {
 		seedType = args[0]
@@ -45,23 +47,18 @@ func main() {
 		logger.Fatal("invalid seed type", zap.String("type", seedType))
 	}
 
-	db, err := store.NewDB(cfg.Database)
+	pool, err := store.NewPool(context.Background(), cfg.Database, logger)
 	if err != nil {
 		logger.Fatal("failed to connect to database", zap.Error(err))
 	}
-	defer func(db *sql.DB) {
-		err := db.Close()
-		if err != nil {
-			panic(err)
-		}
-	}(db)
+	defer pool.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
 	logger.Info("starting database seeding", zap.String("type", seedType))
 
-	if err := seeders.SeedDatabase(ctx, db, logger, cfg, seedType); err != nil {
+	if err := seeders.SeedDatabase(ctx, pool, logger, cfg, seedType); err != nil {
 		logger.Fatal("seeding failed", zap.Error(err))
 	}
 
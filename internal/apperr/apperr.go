@@ -0,0 +1,141 @@
+// Package apperr defines the typed errors handlers render as problem details. An Error
+// carries everything a client may see; its cause is only ever logged.
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code is a stable, machine-readable error identifier. Clients may switch on it, so
+// existing codes must not be renamed.
+type Code string
+
+const (
+	CodeBadRequest      Code = "bad_request"
+	CodeValidation      Code = "validation_failed"
+	CodeUnauthenticated Code = "unauthenticated"
+	CodeForbidden       Code = "forbidden"
+	CodeNotFound        Code = "not_found"
+	CodeConflict        Code = "conflict"
+	CodeUnavailable     Code = "unavailable"
+	CodeInternal        Code = "internal_error"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type Error struct {
+	Code    Code
+	Status  int
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode returns a copy of e with a more specific code, typically used when declaring
+// a domain sentinel such as NotFound("invitation not found").WithCode("invitation_not_found").
+func (e *Error) WithCode(code Code) *Error {
+	c := *e
+	c.Code = code
+	return &c
+}
+
+// WithCause returns a copy of e that records err for the logs.
+func (e *Error) WithCause(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+func New(status int, code Code, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+func Unauthenticated(message string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthenticated, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(http.StatusConflict, CodeConflict, message)
+}
+
+// Validation reports one or more invalid request fields.
+func Validation(fields ...FieldError) *Error {
+	e := New(http.StatusUnprocessableEntity, CodeValidation, "request validation failed")
+	e.Fields = fields
+	return e
+}
+
+// Internal hides err behind a generic message.
+func Internal(err error) *Error {
+	e := New(http.StatusInternalServerError, CodeInternal, "internal server error")
+	e.Err = err
+	return e
+}
+
+// Classifier maps the errors of a lower layer, such as the database driver, to an
+// Error. It returns nil for errors it doesn't recognize.
+type Classifier func(err error) *Error
+
+var classifiers []Classifier
+
+// RegisterClassifier makes From consult c. It is not safe for concurrent use and must be
+// called during startup, before any request is served.
+func RegisterClassifier(c Classifier) {
+	classifiers = append(classifiers, c)
+}
+
+// From maps any error to an Error. Typed errors pass through unchanged; errors a
+// registered Classifier recognizes and context errors get a matching status; everything
+// else is internal.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	for _, classify := range classifiers {
+		if mapped := classify(err); mapped != nil {
+			return mapped
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return New(http.StatusServiceUnavailable, CodeUnavailable, "request timed out").WithCause(err)
+	default:
+		return Internal(err)
+	}
+}
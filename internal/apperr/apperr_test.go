@@ -0,0 +1,70 @@
+package apperr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-web-template/internal/apperr"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AppErrTestSuite struct {
+	suite.Suite
+}
+
+func TestAppErrTestSuite(t *testing.T) {
+	suite.Run(t, new(AppErrTestSuite))
+}
+
+func (suite *AppErrTestSuite) TestFrom_TypedErrorPassesThrough() {
+	sentinel := apperr.NotFound("widget not found").WithCode("widget_not_found")
+
+	mapped := apperr.From(fmt.Errorf("loading widget: %w", sentinel))
+
+	suite.Same(sentinel, mapped)
+	suite.ErrorIs(fmt.Errorf("wrapped: %w", sentinel), sentinel)
+}
+
+func (suite *AppErrTestSuite) TestFrom_WellKnownErrors() {
+	cases := []struct {
+		err    error
+		status int
+		code   apperr.Code
+	}{
+		{context.DeadlineExceeded, http.StatusServiceUnavailable, apperr.CodeUnavailable},
+		{errors.New("connection reset"), http.StatusInternalServerError, apperr.CodeInternal},
+	}
+
+	for _, tc := range cases {
+		mapped := apperr.From(tc.err)
+		suite.Equal(tc.status, mapped.Status, tc.err.Error())
+		suite.Equal(tc.code, mapped.Code, tc.err.Error())
+		suite.ErrorIs(mapped, tc.err)
+	}
+}
+
+func (suite *AppErrTestSuite) TestFrom_RegisteredClassifier() {
+	errGone := errors.New("gone")
+	apperr.RegisterClassifier(func(err error) *apperr.Error {
+		if errors.Is(err, errGone) {
+			return apperr.NotFound("resource not found").WithCause(err)
+		}
+		return nil
+	})
+
+	mapped := apperr.From(fmt.Errorf("loading: %w", errGone))
+	suite.Equal(http.StatusNotFound, mapped.Status)
+	suite.ErrorIs(mapped, errGone)
+
+	suite.Equal(apperr.CodeInternal, apperr.From(errors.New("other")).Code)
+}
+
+func (suite *AppErrTestSuite) TestInternal_HidesCause() {
+	mapped := apperr.From(errors.New("pq: password authentication failed"))
+
+	suite.Equal("internal server error", mapped.Message)
+	suite.Contains(mapped.Error(), "password authentication failed")
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DSN returns the connection string every command connects with. When URL is set it is
+// returned with the options of c added for the parameters it doesn't set; otherwise a
+// keyword/value string is built from the individual settings.
+func (c DatabaseConfig) DSN() string {
+	if c.URL != "" {
+		return c.withOptions(c.URL)
+	}
+
+	params := append([]dsnParam{
+		{"host", c.Host},
+		{"port", c.Port},
+		{"user", c.User},
+		{"password", c.Password},
+		{"dbname", c.DBName},
+	}, c.options()...)
+
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.value != "" {
+			parts = append(parts, p.key+"="+quoteDSNValue(p.value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ReplicaDSNs returns the connection strings of the read replicas, with the same
+// options as DSN.
+func (c DatabaseConfig) ReplicaDSNs() []string {
+	dsns := make([]string, len(c.ReplicaURLs))
+	for i, replicaURL := range c.ReplicaURLs {
+		dsns[i] = c.withOptions(replicaURL)
+	}
+	return dsns
+}
+
+// withOptions adds the options of c to rawURL for the parameters it doesn't set.
+func (c DatabaseConfig) withOptions(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Rejected by Validate; let the driver report it
+		return rawURL
+	}
+	query := u.Query()
+	for _, o := range c.options() {
+		if !query.Has(o.key) {
+			query.Set(o.key, o.value)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+type dsnParam struct {
+	key   string
+	value string
+}
+
+// options are the connection parameters besides the address and credentials. Unset
+// ones are left out so the driver defaults apply.
+func (c DatabaseConfig) options() []dsnParam {
+	var options []dsnParam
+	add := func(key, value string) {
+		if value != "" {
+			options = append(options, dsnParam{key, value})
+		}
+	}
+
+	add("sslmode", c.SSLMode)
+	add("sslrootcert", c.SSLRootCert)
+	add("sslcert", c.SSLCert)
+	add("sslkey", c.SSLKey)
+	if c.ConnectTimeout > 0 {
+		// Whole seconds, rounded up so a sub-second timeout doesn't become "none"
+		add("connect_timeout", strconv.FormatInt(int64((c.ConnectTimeout+time.Second-1)/time.Second), 10))
+	}
+	add("application_name", c.ApplicationName)
+	if c.StatementTimeout > 0 {
+		// Sent to the server as a run-time parameter, in milliseconds
+		add("statement_timeout", strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10))
+	}
+	return options
+}
+
+// quoteDSNValue quotes values with spaces, quotes or backslashes the way libpq expects.
+func quoteDSNValue(value string) string {
+	if !strings.ContainsAny(value, " \t\n'\\") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
@@ -0,0 +1,346 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// field is one setting, found by walking the tagged Config struct.
+type field struct {
+	key    string // dotted file key, e.g. server.port
+	env    string
+	def    string
+	secret bool
+	reload bool
+	value  reflect.Value
+}
+
+// loader applies the configuration layers to a Config, recording which layer set each
+// key and every value that doesn't parse.
+type loader struct {
+	fields   []*field
+	byKey    map[string]*field
+	sources  map[string]string
+	problems []Problem
+}
+
+func newLoader(c *Config) *loader {
+	l := &loader{byKey: make(map[string]*field), sources: make(map[string]string)}
+	l.walk(reflect.ValueOf(c).Elem(), "")
+	return l
+}
+
+func (l *loader) walk(v reflect.Value, prefix string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key, ok := sf.Tag.Lookup("key")
+		if !ok {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+			l.walk(v.Field(i), key)
+			continue
+		}
+
+		f := &field{
+			key:    key,
+			env:    sf.Tag.Get("env"),
+			def:    sf.Tag.Get("default"),
+			secret: sf.Tag.Get("secret") == "true",
+			reload: sf.Tag.Get("reload") == "true",
+			value:  v.Field(i),
+		}
+		l.fields = append(l.fields, f)
+		l.byKey[key] = f
+	}
+}
+
+// load applies every layer and returns the configuration, the arguments after the flags
+// and the config files consulted, including an environment-specific file that doesn't
+// exist (yet).
+func load(args []string) (*Config, []string, []string, error) {
+	c := &Config{}
+	l := newLoader(c)
+
+	flags, configFile, rest, err := l.parseFlags(args)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, f := range l.fields {
+		if err := assign(f.value, f.def); err != nil {
+			panic(fmt.Sprintf("config: bad default for %s: %v", f.key, err))
+		}
+		l.sources[f.key] = "default"
+	}
+
+	if configFile == "" {
+		configFile = os.Getenv("CONFIG_FILE")
+	}
+	var files []string
+	if configFile != "" {
+		files = []string{configFile, environmentFile(configFile, l.environment(c, flags))}
+		l.applyFile(files[0], true)
+		l.applyFile(files[1], false)
+	}
+
+	l.applyEnv()
+
+	for _, f := range l.fields {
+		if value, ok := flags[f.key]; ok {
+			l.set(f, value, "flag -"+f.key)
+		}
+	}
+
+	c.sources = l.sources
+	problems := append(l.problems, c.validate()...)
+	if len(problems) > 0 {
+		return nil, nil, nil, &ValidationError{Problems: problems}
+	}
+	return c, rest, files, nil
+}
+
+// parseFlags parses -config and one flag per key, e.g. -server.port, and returns the
+// keys that were set.
+func (l *loader) parseFlags(args []string) (map[string]string, string, []string, error) {
+	name := "app"
+	if len(os.Args) > 0 {
+		name = filepath.Base(os.Args[0])
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", "", "config file (.yaml, .yml or .toml), or CONFIG_FILE")
+	for _, f := range l.fields {
+		usage := "env " + f.env
+		if f.def != "" {
+			usage += ", default " + f.def
+		}
+		fs.String(f.key, "", usage)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, "", nil, err
+	}
+
+	flags := make(map[string]string)
+	fs.Visit(func(fl *flag.Flag) {
+		if fl.Name != "config" {
+			flags[fl.Name] = fl.Value.String()
+		}
+	})
+	return flags, *configFile, fs.Args(), nil
+}
+
+// environment is the environment the environment-specific file is chosen by, which
+// later layers may set even though the file is applied before them.
+func (l *loader) environment(c *Config, flags map[string]string) string {
+	if env, ok := flags["app.environment"]; ok {
+		return env
+	}
+	if env := os.Getenv("ENVIRONMENT"); env != "" {
+		return env
+	}
+	return c.App.Environment
+}
+
+// environmentFile is config.production.yaml for config.yaml and environment production.
+func environmentFile(path, environment string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + environment + ext
+}
+
+func (l *loader) applyFile(path string, required bool) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return
+	}
+	if err != nil {
+		l.problems = append(l.problems, Problem{Key: "CONFIG_FILE", Message: err.Error()})
+		return
+	}
+
+	values := make(map[string]any)
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		err = toml.Unmarshal(content, &values)
+	default:
+		err = fmt.Errorf("unsupported config file type %q, expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		l.problems = append(l.problems, Problem{Key: "CONFIG_FILE", Message: fmt.Sprintf("%s: %v", path, err)})
+		return
+	}
+
+	l.applyTree(values, "", path)
+}
+
+func (l *loader) applyTree(values map[string]any, prefix, path string) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := values[name]
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			l.applyTree(nested, key, path)
+			continue
+		}
+
+		f, ok := l.byKey[key]
+		if !ok {
+			l.problems = append(l.problems, Problem{Key: key, Message: "unknown setting in " + path})
+			continue
+		}
+		if value == nil {
+			continue
+		}
+
+		if list, ok := value.([]any); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				items[i] = scalar(item)
+			}
+			l.set(f, items, key+" in "+path)
+			continue
+		}
+		l.set(f, scalar(value), key+" in "+path)
+	}
+}
+
+// applyEnv reads each field's variable, or the file its <VAR>_FILE variable names.
+// Empty variables are ignored.
+func (l *loader) applyEnv() {
+	for _, f := range l.fields {
+		value := os.Getenv(f.env)
+		secretFile := os.Getenv(f.env + "_FILE")
+
+		switch {
+		case value != "" && secretFile != "":
+			l.problems = append(l.problems, Problem{Key: f.env, Message: "both " + f.env + " and " + f.env + "_FILE are set"})
+		case secretFile != "":
+			content, err := os.ReadFile(secretFile)
+			if err != nil {
+				l.problems = append(l.problems, Problem{Key: f.env + "_FILE", Message: err.Error()})
+				continue
+			}
+			// Secret files usually end with a newline that isn't part of the value
+			l.set(f, strings.TrimRight(string(content), "\r\n"), "env "+f.env+"_FILE")
+		case value != "":
+			l.set(f, value, "env "+f.env)
+		}
+	}
+}
+
+// set assigns a string or []string to f. Problems are reported against the variable,
+// naming the source unless it is the variable itself.
+func (l *loader) set(f *field, value any, source string) {
+	if err := assign(f.value, value); err != nil {
+		message := err.Error()
+		if source != "env "+f.env {
+			message += " (" + source + ")"
+		}
+		l.problems = append(l.problems, Problem{Key: f.env, Message: message})
+		return
+	}
+	l.sources[f.key] = source
+}
+
+func assign(v reflect.Value, value any) error {
+	if items, ok := value.([]string); ok {
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("%q is a list, expected a single value", items)
+		}
+		v.Set(reflect.ValueOf(items))
+		return nil
+	}
+
+	s := value.(string)
+	switch {
+	case v.Type() == durationType:
+		// Plain integers are seconds, as the variables were originally documented
+		if seconds, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			v.SetInt(int64(time.Duration(seconds) * time.Second))
+			return nil
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("%q is not a duration, e.g. 600 (seconds) or 10m", s)
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		v.SetFloat(n)
+	case v.Kind() == reflect.Slice:
+		// Comma-separated, ignoring blanks around and between items
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		panic("config: unsupported field type " + v.Type().String())
+	}
+	return nil
+}
+
+// scalar formats a value decoded from a config file for assign.
+func scalar(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[redacted]"
+
+// Print writes c in the YAML config file format with secrets redacted. Each value is
+// annotated with the layer that set it, e.g. "# env SERVER_PORT".
+func (c *Config) Print(w io.Writer) error {
+	l := newLoader(c)
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := make(map[string]*yaml.Node)
+
+	for _, f := range l.fields {
+		section, name, _ := strings.Cut(f.key, ".")
+		parent, ok := sections[section]
+		if !ok {
+			parent = &yaml.Node{Kind: yaml.MappingNode}
+			sections[section] = parent
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: section}, parent)
+		}
+
+		value := printValue(f)
+		if source := c.sources[f.key]; source != "" {
+			value.LineComment = source
+		}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func printValue(f *field) *yaml.Node {
+	if f.secret && !f.value.IsZero() {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: redacted}
+	}
+
+	switch v := f.value.Interface().(type) {
+	case time.Duration:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.String()}
+	case []string:
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range v {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+		}
+		return seq
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	default:
+		// int, bool and float64
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	// reloadMu serializes reloads, so subscribers see configurations in order
+	reloadMu sync.Mutex
+
+	subscribersMu sync.Mutex
+	subscribers   = make(map[int]func(*Config))
+	nextID        int
+)
+
+// ReloadResult lists the keys that differed in the reloaded sources.
+type ReloadResult struct {
+	// Applied are reloadable settings now in effect
+	Applied []string
+	// Ignored changed too but only take effect after a restart
+	Ignored []string
+}
+
+// Subscribe calls fn with the new configuration after every reload that applied a
+// change. fn runs on the reloading goroutine and should not block. Call the returned
+// function to unsubscribe.
+func Subscribe(fn func(*Config)) (unsubscribe func()) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	id := nextID
+	nextID++
+	subscribers[id] = fn
+
+	return func() {
+		subscribersMu.Lock()
+		defer subscribersMu.Unlock()
+		delete(subscribers, id)
+	}
+}
+
+// Reload reads the sources LoadArgs was given again. Only settings tagged reload:"true",
+// e.g. the log level, are applied; other changes are reported in Ignored. A
+// configuration that fails validation is rejected as a whole and the current one is
+// kept.
+func Reload() (*ReloadResult, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	input := loaded.Load()
+	if input == nil {
+		panic("config not loaded")
+	}
+
+	next, _, _, err := load(input.args)
+	if err != nil {
+		return nil, err
+	}
+
+	old := Get()
+	merged := *old
+	merged.sources = make(map[string]string, len(old.sources))
+	for key, source := range old.sources {
+		merged.sources[key] = source
+	}
+
+	result := &ReloadResult{}
+	nextFields := newLoader(next).byKey
+	for _, f := range newLoader(&merged).fields {
+		value := nextFields[f.key].value
+		if reflect.DeepEqual(f.value.Interface(), value.Interface()) {
+			continue
+		}
+		if !f.reload {
+			result.Ignored = append(result.Ignored, f.key)
+			continue
+		}
+		f.value.Set(value)
+		merged.sources[f.key] = next.sources[f.key]
+		result.Applied = append(result.Applied, f.key)
+	}
+
+	if len(result.Applied) == 0 {
+		return result, nil
+	}
+	if err := merged.Validate(); err != nil {
+		return nil, err
+	}
+
+	current.Store(&merged)
+
+	subscribersMu.Lock()
+	notify := make([]func(*Config), 0, len(subscribers))
+	for _, fn := range subscribers {
+		notify = append(notify, fn)
+	}
+	subscribersMu.Unlock()
+
+	for _, fn := range notify {
+		fn(&merged)
+	}
+	return result, nil
+}
+
+// Watch checks the config files every interval until ctx is done, and calls onChange
+// when one is modified, created or removed. Files are compared by size and
+// modification time, which also catches the symlink swaps Kubernetes uses to update
+// mounted ConfigMaps.
+func Watch(ctx context.Context, interval time.Duration, onChange func()) {
+	input := loaded.Load()
+	if input == nil || len(input.files) == 0 {
+		return
+	}
+
+	last := stat(input.files)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if next := stat(input.files); !reflect.DeepEqual(next, last) {
+				last = next
+				onChange()
+			}
+		}
+	}
+}
+
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func stat(files []string) []fileState {
+	states := make([]fileState, len(files))
+	for i, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			states[i] = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+		}
+	}
+	return states
+}
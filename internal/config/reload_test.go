@@ -0,0 +1,122 @@
+package config_test
+
+import (
+	"context"
+	"go-web-template/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReloadTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestReloadTestSuite(t *testing.T) {
+	suite.Run(t, new(ReloadTestSuite))
+}
+
+func (suite *ReloadTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "config.yaml")
+	suite.T().Setenv("ENVIRONMENT", "local")
+	suite.T().Setenv("CONFIG_FILE", suite.path)
+
+	suite.write(`
+server:
+  port: 9000
+app:
+  log_level: info
+`)
+	suite.Require().NoError(config.Load())
+}
+
+func (suite *ReloadTestSuite) write(content string) {
+	suite.Require().NoError(os.WriteFile(suite.path, []byte(content), 0o600))
+}
+
+func (suite *ReloadTestSuite) TestAppliesReloadableSettingsOnly() {
+	var notified []*config.Config
+	unsubscribe := config.Subscribe(func(c *config.Config) {
+		notified = append(notified, c)
+	})
+	defer unsubscribe()
+
+	suite.write(`
+server:
+  port: 9100
+  allowed_origins: [https://app.example.com]
+app:
+  log_level: warn
+`)
+	result, err := config.Reload()
+	suite.Require().NoError(err)
+
+	suite.Equal([]string{"server.allowed_origins", "app.log_level"}, result.Applied)
+	suite.Equal([]string{"server.port"}, result.Ignored)
+
+	c := config.Get()
+	suite.Equal("warn", c.App.LogLevel)
+	suite.Equal([]string{"https://app.example.com"}, c.Server.AllowedOrigins)
+	suite.Equal("9000", c.Server.Port)
+
+	suite.Require().Len(notified, 1)
+	suite.Same(c, notified[0])
+}
+
+func (suite *ReloadTestSuite) TestRejectsInvalidConfiguration() {
+	notified := 0
+	unsubscribe := config.Subscribe(func(*config.Config) { notified++ })
+	defer unsubscribe()
+
+	suite.write(`
+app:
+  log_level: verbose
+`)
+	_, err := config.Reload()
+
+	suite.ErrorContains(err, "LOG_LEVEL")
+	suite.Equal("info", config.Get().App.LogLevel)
+	suite.Zero(notified)
+}
+
+func (suite *ReloadTestSuite) TestUnchangedSourcesNotifyNobody() {
+	notified := 0
+	unsubscribe := config.Subscribe(func(*config.Config) { notified++ })
+	defer unsubscribe()
+
+	result, err := config.Reload()
+
+	suite.Require().NoError(err)
+	suite.Empty(result.Applied)
+	suite.Zero(notified)
+}
+
+func (suite *ReloadTestSuite) TestWatchDetectsChanges() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changed := make(chan struct{}, 1)
+	go config.Watch(ctx, 5*time.Millisecond, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
+	// Let Watch record the initial state before changing the file
+	time.Sleep(20 * time.Millisecond)
+	suite.write(`
+app:
+  log_level: error
+`)
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		suite.Fail("change not detected")
+	}
+}
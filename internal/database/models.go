@@ -5,25 +5,67 @@
 package database
 
 import (
-	"database/sql"
 	"time"
 )
 
+type AuditEvent struct {
+	ID         int64     `json:"id"`
+	ActorID    *int64    `json:"actor_id"`
+	Action     string    `json:"action"`
+	TargetType *string   `json:"target_type"`
+	TargetID   *int64    `json:"target_id"`
+	IpAddress  *string   `json:"ip_address"`
+	RequestID  *string   `json:"request_id"`
+	Changes    []byte    `json:"changes"`
+	Metadata   []byte    `json:"metadata"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Invitation struct {
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	RoleID         int64      `json:"role_id"`
+	InvitedBy      *int64     `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	OrganizationID *int64     `json:"organization_id"`
+}
+
+type Organization struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64     `json:"organization_id"`
+	UserID         int64     `json:"user_id"`
+	RoleID         int64     `json:"role_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
 type Permission struct {
-	ID          int64          `json:"id"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type Role struct {
-	ID          int64          `json:"id"`
-	Name        string         `json:"name"`
-	IsDefault   bool           `json:"is_default"`
-	Description sql.NullString `json:"description"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	IsDefault          bool      `json:"is_default"`
+	Description        *string   `json:"description"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	OrganizationScoped bool      `json:"organization_scoped"`
 }
 
 type RolePermission struct {
@@ -34,13 +76,13 @@ type RolePermission struct {
 }
 
 type User struct {
-	ID             int64        `json:"id"`
-	Email          string       `json:"email"`
-	Password       string       `json:"password"`
-	DisplayName    string       `json:"display_name"`
-	EmailConfirmed sql.NullTime `json:"email_confirmed"`
-	RoleID         int64        `json:"role_id"`
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
-	DeletedAt      sql.NullTime `json:"deleted_at"`
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	Password       string     `json:"password"`
+	DisplayName    string     `json:"display_name"`
+	EmailConfirmed *time.Time `json:"email_confirmed"`
+	RoleID         int64      `json:"role_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
 }
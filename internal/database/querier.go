@@ -6,20 +6,49 @@ package database
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (OrganizationMember, error)
 	AssignPermissionToRole(ctx context.Context, arg AssignPermissionToRoleParams) error
+	CanAssignOrganizationRole(ctx context.Context, arg CanAssignOrganizationRoleParams) (bool, error)
+	CountAuditEvents(ctx context.Context, arg CountAuditEventsParams) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	CreateInvitation(ctx context.Context, arg CreateInvitationParams) (Invitation, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
 	CreatePermission(ctx context.Context, arg CreatePermissionParams) (Permission, error)
 	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	GetDefaultRole(ctx context.Context) (Role, error)
+	GetInvitationByID(ctx context.Context, id int64) (Invitation, error)
+	GetInvitationByIDForUpdate(ctx context.Context, id int64) (Invitation, error)
+	GetOrganizationByID(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (Organization, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	GetPendingInvitationByEmail(ctx context.Context, arg GetPendingInvitationByEmailParams) (Invitation, error)
 	GetPermissionByName(ctx context.Context, name string) (Permission, error)
+	GetRoleByID(ctx context.Context, id int64) (Role, error)
 	GetRoleByName(ctx context.Context, name string) (Role, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
+	HoldsRolePermissions(ctx context.Context, arg HoldsRolePermissionsParams) (bool, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListInvitations(ctx context.Context) ([]Invitation, error)
+	ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]Invitation, error)
+	ListOrganizationMembers(ctx context.Context, organizationID int64) ([]ListOrganizationMembersRow, error)
+	ListOrganizationsForUser(ctx context.Context, userID int64) ([]ListOrganizationsForUserRow, error)
+	ListRolePermissionNames(ctx context.Context, roleID int64) ([]string, error)
 	ListUsersPaginated(ctx context.Context, arg ListUsersPaginatedParams) ([]User, error)
+	LockOrganizationMembersWithRole(ctx context.Context, arg LockOrganizationMembersWithRoleParams) ([]int64, error)
+	MarkInvitationAccepted(ctx context.Context, id int64) (int64, error)
+	MemberHasPermission(ctx context.Context, arg MemberHasPermissionParams) (bool, error)
+	PurgeAuditEventsBefore(ctx context.Context, before time.Time) (int64, error)
+	RemoveOrganizationMember(ctx context.Context, arg RemoveOrganizationMemberParams) (int64, error)
+	RevokeInvitation(ctx context.Context, arg RevokeInvitationParams) (int64, error)
+	UpdateOrganizationMemberRole(ctx context.Context, arg UpdateOrganizationMemberRoleParams) (int64, error)
+	UserHasPermission(ctx context.Context, arg UserHasPermissionParams) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -7,7 +7,6 @@ package database
 
 import (
 	"context"
-	"database/sql"
 )
 
 const assignPermissionToRole = `-- name: AssignPermissionToRole :exec
@@ -22,10 +21,55 @@ type AssignPermissionToRoleParams struct {
 }
 
 func (q *Queries) AssignPermissionToRole(ctx context.Context, arg AssignPermissionToRoleParams) error {
-	_, err := q.db.ExecContext(ctx, assignPermissionToRole, arg.RoleID, arg.PermissionID)
+	_, err := q.db.Exec(ctx, assignPermissionToRole, arg.RoleID, arg.PermissionID)
 	return err
 }
 
+const canAssignOrganizationRole = `-- name: CanAssignOrganizationRole :one
+SELECT EXISTS (
+    SELECT 1 FROM roles r
+    WHERE r.id = $1
+      AND r.organization_scoped
+      AND NOT EXISTS (
+          SELECT 1 FROM role_permissions rp
+          JOIN permissions p ON p.id = rp.permission_id
+          WHERE rp.role_id = r.id
+            AND (p.name = 'system:superadmin' OR (
+                $2::bigint IS NOT NULL
+                AND NOT EXISTS (
+                    SELECT 1 FROM organization_members m
+                    JOIN role_permissions mrp ON mrp.role_id = m.role_id
+                    JOIN permissions mp ON mp.id = mrp.permission_id
+                    WHERE m.organization_id = $3
+                      AND m.user_id = $2
+                      AND mp.name IN (p.name, 'system:superadmin')
+                )
+                AND NOT EXISTS (
+                    SELECT 1 FROM users u
+                    JOIN role_permissions urp ON urp.role_id = u.role_id
+                    JOIN permissions up ON up.id = urp.permission_id
+                    WHERE u.id = $2
+                      AND u.deleted_at IS NULL
+                      AND up.name = 'system:superadmin'
+                )
+            ))
+      )
+) AS assignable
+`
+
+type CanAssignOrganizationRoleParams struct {
+	RoleID         int64  `json:"role_id"`
+	ActorID        *int64 `json:"actor_id"`
+	OrganizationID int64  `json:"organization_id"`
+}
+
+func (q *Queries) CanAssignOrganizationRole(ctx context.Context, arg CanAssignOrganizationRoleParams) (bool, error) {
+	row := q.db.QueryRow(ctx, canAssignOrganizationRole, arg.RoleID, arg.ActorID, arg.OrganizationID)
+	var assignable bool
+	err := row.Scan(&assignable)
+	return assignable, err
+}
+
 const createPermission = `-- name: CreatePermission :one
 INSERT INTO permissions (name, description)
 VALUES ($1, $2)
@@ -33,12 +77,12 @@ VALUES ($1, $2)
 `
 
 type CreatePermissionParams struct {
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
 }
 
 func (q *Queries) CreatePermission(ctx context.Context, arg CreatePermissionParams) (Permission, error) {
-	row := q.db.QueryRowContext(ctx, createPermission, arg.Name, arg.Description)
+	row := q.db.QueryRow(ctx, createPermission, arg.Name, arg.Description)
 	var i Permission
 	err := row.Scan(
 		&i.ID,
@@ -51,19 +95,25 @@ func (q *Queries) CreatePermission(ctx context.Context, arg CreatePermissionPara
 }
 
 const createRole = `-- name: CreateRole :one
-INSERT INTO roles (name, is_default, description)
-VALUES ($1, $2, $3)
-    RETURNING id, name, is_default, description, created_at, updated_at
+INSERT INTO roles (name, is_default, description, organization_scoped)
+VALUES ($1, $2, $3, $4)
+    RETURNING id, name, is_default, description, created_at, updated_at, organization_scoped
 `
 
 type CreateRoleParams struct {
-	Name        string         `json:"name"`
-	IsDefault   bool           `json:"is_default"`
-	Description sql.NullString `json:"description"`
+	Name               string  `json:"name"`
+	IsDefault          bool    `json:"is_default"`
+	Description        *string `json:"description"`
+	OrganizationScoped bool    `json:"organization_scoped"`
 }
 
 func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error) {
-	row := q.db.QueryRowContext(ctx, createRole, arg.Name, arg.IsDefault, arg.Description)
+	row := q.db.QueryRow(ctx, createRole,
+		arg.Name,
+		arg.IsDefault,
+		arg.Description,
+		arg.OrganizationScoped,
+	)
 	var i Role
 	err := row.Scan(
 		&i.ID,
@@ -72,6 +122,7 @@ func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, e
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationScoped,
 	)
 	return i, err
 }
@@ -81,7 +132,7 @@ SELECT id, name, description, created_at, updated_at FROM permissions WHERE name
 `
 
 func (q *Queries) GetPermissionByName(ctx context.Context, name string) (Permission, error) {
-	row := q.db.QueryRowContext(ctx, getPermissionByName, name)
+	row := q.db.QueryRow(ctx, getPermissionByName, name)
 	var i Permission
 	err := row.Scan(
 		&i.ID,
@@ -93,12 +144,31 @@ func (q *Queries) GetPermissionByName(ctx context.Context, name string) (Permiss
 	return i, err
 }
 
+const getRoleByID = `-- name: GetRoleByID :one
+SELECT id, name, is_default, description, created_at, updated_at, organization_scoped FROM roles WHERE id = $1
+`
+
+func (q *Queries) GetRoleByID(ctx context.Context, id int64) (Role, error) {
+	row := q.db.QueryRow(ctx, getRoleByID, id)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsDefault,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationScoped,
+	)
+	return i, err
+}
+
 const getRoleByName = `-- name: GetRoleByName :one
-SELECT id, name, is_default, description, created_at, updated_at FROM roles WHERE name = $1
+SELECT id, name, is_default, description, created_at, updated_at, organization_scoped FROM roles WHERE name = $1
 `
 
 func (q *Queries) GetRoleByName(ctx context.Context, name string) (Role, error) {
-	row := q.db.QueryRowContext(ctx, getRoleByName, name)
+	row := q.db.QueryRow(ctx, getRoleByName, name)
 	var i Role
 	err := row.Scan(
 		&i.ID,
@@ -107,6 +177,70 @@ func (q *Queries) GetRoleByName(ctx context.Context, name string) (Role, error)
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationScoped,
 	)
 	return i, err
 }
+
+const holdsRolePermissions = `-- name: HoldsRolePermissions :one
+SELECT NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    JOIN permissions p ON p.id = rp.permission_id
+    WHERE rp.role_id = $1
+      AND NOT EXISTS (
+          SELECT 1 FROM organization_members m
+          JOIN role_permissions mrp ON mrp.role_id = m.role_id
+          JOIN permissions mp ON mp.id = mrp.permission_id
+          WHERE m.organization_id = $2
+            AND m.user_id = $3
+            AND mp.name IN (p.name, 'system:superadmin')
+      )
+) OR EXISTS (
+    SELECT 1 FROM users u
+    JOIN role_permissions urp ON urp.role_id = u.role_id
+    JOIN permissions up ON up.id = urp.permission_id
+    WHERE u.id = $3
+      AND u.deleted_at IS NULL
+      AND up.name = 'system:superadmin'
+) AS holds
+`
+
+type HoldsRolePermissionsParams struct {
+	RoleID         int64 `json:"role_id"`
+	OrganizationID int64 `json:"organization_id"`
+	ActorID        int64 `json:"actor_id"`
+}
+
+func (q *Queries) HoldsRolePermissions(ctx context.Context, arg HoldsRolePermissionsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, holdsRolePermissions, arg.RoleID, arg.OrganizationID, arg.ActorID)
+	var holds bool
+	err := row.Scan(&holds)
+	return holds, err
+}
+
+const listRolePermissionNames = `-- name: ListRolePermissionNames :many
+SELECT p.name FROM permissions p
+JOIN role_permissions rp ON rp.permission_id = p.id
+WHERE rp.role_id = $1
+ORDER BY p.name
+`
+
+func (q *Queries) ListRolePermissionNames(ctx context.Context, roleID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, listRolePermissionNames, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
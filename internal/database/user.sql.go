@@ -15,7 +15,7 @@ WHERE deleted_at IS NULL
 `
 
 func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countUsers)
+	row := q.db.QueryRow(ctx, countUsers)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -35,7 +35,7 @@ type CreateUserParams struct {
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, createUser,
+	row := q.db.QueryRow(ctx, createUser,
 		arg.Email,
 		arg.Password,
 		arg.DisplayName,
@@ -57,13 +57,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 }
 
 const getDefaultRole = `-- name: GetDefaultRole :one
-SELECT id, name, is_default, description, created_at, updated_at FROM roles
+SELECT id, name, is_default, description, created_at, updated_at, organization_scoped FROM roles
 WHERE is_default = true
     LIMIT 1
 `
 
 func (q *Queries) GetDefaultRole(ctx context.Context) (Role, error) {
-	row := q.db.QueryRowContext(ctx, getDefaultRole)
+	row := q.db.QueryRow(ctx, getDefaultRole)
 	var i Role
 	err := row.Scan(
 		&i.ID,
@@ -72,17 +72,18 @@ func (q *Queries) GetDefaultRole(ctx context.Context) (Role, error) {
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationScoped,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT id, email, password, display_name, email_confirmed, role_id, created_at, updated_at, deleted_at FROM users
-WHERE email = $1 AND deleted_at IS NULL
+WHERE lower(email) = lower($1) AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -104,7 +105,7 @@ WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	row := q.db.QueryRow(ctx, getUserByID, id)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -133,7 +134,7 @@ type ListUsersPaginatedParams struct {
 }
 
 func (q *Queries) ListUsersPaginated(ctx context.Context, arg ListUsersPaginatedParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsersPaginated, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listUsersPaginated, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -156,11 +157,31 @@ func (q *Queries) ListUsersPaginated(ctx context.Context, arg ListUsersPaginated
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
+
+const userHasPermission = `-- name: UserHasPermission :one
+SELECT EXISTS (
+    SELECT 1 FROM users u
+    JOIN role_permissions rp ON rp.role_id = u.role_id
+    JOIN permissions p ON p.id = rp.permission_id
+    WHERE u.id = $1
+      AND u.deleted_at IS NULL
+      AND (p.name = $2 OR p.name = 'system:superadmin')
+)
+`
+
+type UserHasPermissionParams struct {
+	UserID     int64  `json:"user_id"`
+	Permission string `json:"permission"`
+}
+
+func (q *Queries) UserHasPermission(ctx context.Context, arg UserHasPermissionParams) (bool, error) {
+	row := q.db.QueryRow(ctx, userHasPermission, arg.UserID, arg.Permission)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/openapi"
+	"go-web-template/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AuditHandler struct {
+	service     AuditServiceInterface
+	permissions middleware.PermissionMiddlewareInterface
+}
+
+func NewAuditHandler(srv AuditServiceInterface, permissions middleware.PermissionMiddlewareInterface) *AuditHandler {
+	return &AuditHandler{
+		service:     srv,
+		permissions: permissions,
+	}
+}
+
+// Routes expects to be mounted behind WebClientAuthentication.
+func (h *AuditHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(h.permissions.Require("audit:read"))
+	r.Get("/", h.ListEvents)
+	return r
+}
+
+// Operations documents Routes for the OpenAPI spec.
+func (h *AuditHandler) Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/", ID: "listAuditEvents", Summary: "List audit events, newest first", Response: PaginatedEvents{}, Auth: true, Permission: "audit:read",
+			Query: []openapi.Param{
+				{Name: "actor_id", Type: "integer", Format: "int64"},
+				{Name: "action", Type: "string", Description: "e.g. auth.login"},
+				{Name: "target_type", Type: "string"},
+				{Name: "target_id", Type: "integer", Format: "int64"},
+				{Name: "since", Type: "string", Format: "date-time"},
+				{Name: "until", Type: "string", Format: "date-time"},
+				{Name: "page", Type: "integer"},
+				{Name: "page_size", Type: "integer", Description: "Events per page, at most 100"},
+			},
+		},
+	}
+}
+
+func (h *AuditHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := Filter{
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+	}
+
+	var err error
+	if filter.ActorID, err = parseOptionalInt(query.Get("actor_id")); err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid actor_id"))
+		return
+	}
+	if filter.TargetID, err = parseOptionalInt(query.Get("target_id")); err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid target_id"))
+		return
+	}
+	if filter.Since, err = parseOptionalTime(query.Get("since")); err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid since, expected RFC 3339"))
+		return
+	}
+	if filter.Until, err = parseOptionalTime(query.Get("until")); err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid until, expected RFC 3339"))
+		return
+	}
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	events, err := h.service.ListEvents(r.Context(), filter, page, pageSize)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, events)
+}
+
+func parseOptionalInt(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,127 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"go-web-template/internal/config"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-web-template/mocks"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type staticPermission bool
+
+func (p staticPermission) HasPermission(context.Context, int64, string) (bool, error) {
+	return bool(p), nil
+}
+
+func (p staticPermission) HasOrganizationPermission(context.Context, int64, int64, string) (bool, error) {
+	return bool(p), nil
+}
+
+type AuditHandlerTestSuite struct {
+	suite.Suite
+	mockService    *mocks.MockAuditServiceInterface
+	authMiddleware *middleware.AuthMiddleware
+}
+
+func (suite *AuditHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{}
+	cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+
+	suite.authMiddleware = middleware.NewAuthMiddleware(cfg, zap.NewNop(), time.Minute, time.Hour, time.Hour)
+	suite.mockService = mocks.NewMockAuditServiceInterface(suite.T())
+}
+
+func TestAuditHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditHandlerTestSuite))
+}
+
+func (suite *AuditHandlerTestSuite) router(allowed bool) *chi.Mux {
+	permissions := middleware.NewPermissionMiddleware(staticPermission(allowed), staticPermission(allowed), zap.NewNop())
+	handler := audit.NewAuditHandler(suite.mockService, permissions)
+
+	r := chi.NewRouter()
+	r.Use(suite.authMiddleware.WebClientAuthentication)
+	r.Mount("/audit", handler.Routes())
+	return r
+}
+
+func (suite *AuditHandlerTestSuite) authenticatedRequest(target string) *http.Request {
+	accessToken, _, err := suite.authMiddleware.GenerateLoginTokens(7, false)
+	suite.Require().NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.AddCookie(&http.Cookie{Name: "access", Value: accessToken})
+	return req
+}
+
+func (suite *AuditHandlerTestSuite) TestListEvents_Filters() {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	actorID := int64(3)
+
+	suite.mockService.EXPECT().
+		ListEvents(mock.Anything, audit.Filter{
+			ActorID: &actorID,
+			Action:  audit.ActionLogin,
+			Since:   &since,
+		}, 2, 10).
+		Return(&audit.PaginatedEvents{
+			Data:     []*audit.AuditEvent{{ID: 1, Action: audit.ActionLogin}},
+			Page:     2,
+			PageSize: 10,
+			Total:    11,
+		}, nil).
+		Once()
+
+	req := suite.authenticatedRequest("/audit?actor_id=3&action=auth.login&since=2026-01-01T00:00:00Z&page=2&page_size=10")
+	w := httptest.NewRecorder()
+
+	suite.router(true).ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response audit.PaginatedEvents
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Len(response.Data, 1)
+	suite.Equal(11, response.Total)
+}
+
+func (suite *AuditHandlerTestSuite) TestListEvents_InvalidSince() {
+	req := suite.authenticatedRequest("/audit?since=yesterday")
+	w := httptest.NewRecorder()
+
+	suite.router(true).ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *AuditHandlerTestSuite) TestListEvents_Forbidden() {
+	req := suite.authenticatedRequest("/audit")
+	w := httptest.NewRecorder()
+
+	suite.router(false).ServeHTTP(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
+
+func (suite *AuditHandlerTestSuite) TestDiff_OnlyChangedFields() {
+	changes, err := audit.Diff(
+		map[string]any{"role_id": 2, "name": "Acme"},
+		map[string]any{"role_id": 3, "name": "Acme"},
+	)
+	suite.Require().NoError(err)
+	suite.JSONEq(`{"role_id": {"before": 2, "after": 3}}`, string(changes))
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActionLogin               = "auth.login"
+	ActionLoginFailed         = "auth.login_failed"
+	ActionUserCreated         = "user.created"
+	ActionInvitationCreated   = "invitation.created"
+	ActionInvitationRevoked   = "invitation.revoked"
+	ActionInvitationAccepted  = "invitation.accepted"
+	ActionOrganizationCreated = "organization.created"
+	ActionMemberAdded         = "organization.member_added"
+	ActionMemberRoleChanged   = "organization.member_role_changed"
+	ActionMemberRemoved       = "organization.member_removed"
+)
+
+const (
+	TargetUser         = "user"
+	TargetInvitation   = "invitation"
+	TargetOrganization = "organization"
+)
+
+// Event is what other domains hand to Record. Before and After are snapshots of the
+// target; only the fields that differ between them are stored.
+type Event struct {
+	ActorID    *int64
+	Action     string
+	TargetType string
+	TargetID   *int64
+	Before     any
+	After      any
+	Metadata   map[string]any
+}
+
+type AuditEvent struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type,omitempty"`
+	TargetID   *int64          `json:"target_id,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Changes    json.RawMessage `json:"changes"`
+	Metadata   json.RawMessage `json:"metadata"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type Filter struct {
+	ActorID    *int64
+	Action     string
+	TargetType string
+	TargetID   *int64
+	Since      *time.Time
+	Until      *time.Time
+}
+
+type PaginatedEvents struct {
+	Data       []*AuditEvent `json:"data"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	Total      int           `json:"total"`
+	TotalPages int           `json:"total_pages"`
+}
+
+type fieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
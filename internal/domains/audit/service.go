@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"go-web-template/internal/database"
+	"go-web-template/internal/reqctx"
+	"go-web-template/internal/tracing"
+	"reflect"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+)
+
+// Recorder is the part of the audit service other domains depend on. Passing the
+// caller's queries lets the event commit or roll back with the caller's transaction.
+type Recorder interface {
+	Record(ctx context.Context, q database.Querier, evt Event) error
+}
+
+type AuditServiceInterface interface {
+	Recorder
+	ListEvents(ctx context.Context, filter Filter, page, pageSize int) (*PaginatedEvents, error)
+	PurgeBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+var _ AuditServiceInterface = (*AuditService)(nil)
+
+type AuditService struct {
+	queries database.Querier
+}
+
+func NewAuditService(queries database.Querier) *AuditService {
+	return &AuditService{
+		queries: queries,
+	}
+}
+
+func toAuditEventModel(dbEvent database.AuditEvent) *AuditEvent {
+	return &AuditEvent{
+		ID:         dbEvent.ID,
+		ActorID:    dbEvent.ActorID,
+		Action:     dbEvent.Action,
+		TargetType: stringValue(dbEvent.TargetType),
+		TargetID:   dbEvent.TargetID,
+		IPAddress:  stringValue(dbEvent.IpAddress),
+		RequestID:  stringValue(dbEvent.RequestID),
+		Changes:    dbEvent.Changes,
+		Metadata:   dbEvent.Metadata,
+		CreatedAt:  dbEvent.CreatedAt,
+	}
+}
+
+// Record stores evt using q, or the service's own connection when q is nil. The actor
+// defaults to the authenticated user, and the client IP and request ID are taken from ctx.
+func (s *AuditService) Record(ctx context.Context, q database.Querier, evt Event) error {
+	if q == nil {
+		q = s.queries
+	}
+
+	changes, err := Diff(evt.Before, evt.After)
+	if err != nil {
+		return err
+	}
+
+	metadata := evt.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	actorID := evt.ActorID
+	if actorID == nil {
+		if userID, ok := reqctx.UserID(ctx); ok {
+			actorID = &userID
+		}
+	}
+
+	_, err = q.CreateAuditEvent(ctx, database.CreateAuditEventParams{
+		ActorID:    actorID,
+		Action:     evt.Action,
+		TargetType: nullString(evt.TargetType),
+		TargetID:   evt.TargetID,
+		IpAddress:  nullString(reqctx.ClientIP(ctx)),
+		RequestID:  nullString(chimw.GetReqID(ctx)),
+		Changes:    changes,
+		Metadata:   metadataJSON,
+	})
+	return err
+}
+
+func (s *AuditService) ListEvents(ctx context.Context, filter Filter, page, pageSize int) (*PaginatedEvents, error) {
+	ctx, span := tracing.Start(ctx, "audit.ListEvents")
+	defer span.End()
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	offset := (page - 1) * pageSize
+
+	dbEvents, err := s.queries.ListAuditEvents(ctx, database.ListAuditEventsParams{
+		ActorID:    filter.ActorID,
+		Action:     nullString(filter.Action),
+		TargetType: nullString(filter.TargetType),
+		TargetID:   filter.TargetID,
+		Since:      filter.Since,
+		Until:      filter.Until,
+		PageLimit:  int32(pageSize),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.queries.CountAuditEvents(ctx, database.CountAuditEventsParams{
+		ActorID:    filter.ActorID,
+		Action:     nullString(filter.Action),
+		TargetType: nullString(filter.TargetType),
+		TargetID:   filter.TargetID,
+		Since:      filter.Since,
+		Until:      filter.Until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*AuditEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = toAuditEventModel(dbEvent)
+	}
+
+	totalPages := (int(total) + pageSize - 1) / pageSize
+
+	return &PaginatedEvents{
+		Data:       events,
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      int(total),
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *AuditService) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "audit.PurgeBefore")
+	defer span.End()
+
+	return s.queries.PurgeAuditEventsBefore(ctx, before)
+}
+
+// Diff returns the fields that differ between two JSON-serializable snapshots as
+// {"field": {"before": ..., "after": ...}}. A nil before or after describes a create or delete.
+func Diff(before, after any) (json.RawMessage, error) {
+	b, err := toFieldMap(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toFieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]fieldChange)
+	for key, value := range b {
+		if !reflect.DeepEqual(value, a[key]) {
+			changes[key] = fieldChange{Before: value, After: a[key]}
+		}
+	}
+	for key, value := range a {
+		if _, seen := b[key]; !seen {
+			changes[key] = fieldChange{After: value}
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+func toFieldMap(v any) (map[string]any, error) {
+	if v == nil {
+		return map[string]any{}, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		// Not an object, e.g. a bare role ID
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return map[string]any{"value": value}, nil
+	}
+
+	return fields, nil
+}
+
+// nullString stores empty strings as NULL.
+func nullString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -1,27 +1,42 @@
 package auth
 
 import (
-	"encoding/json"
+	"errors"
+	"go-web-template/internal/metrics"
 	"go-web-template/internal/middleware"
+	"go-web-template/internal/openapi"
 	"go-web-template/internal/utils"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type AuthHandler struct {
-	service        AuthServiceInterface
-	authMiddleware middleware.AuthMiddlewareInterface
+	service             AuthServiceInterface
+	authMiddleware      middleware.AuthMiddlewareInterface
+	metrics             *metrics.Metrics
+	registrationEnabled atomic.Bool
 }
 
 func NewAuthHandler(
 	srv AuthServiceInterface,
 	authMiddleware middleware.AuthMiddlewareInterface,
+	metrics *metrics.Metrics,
+	registrationEnabled bool,
 ) *AuthHandler {
-	return &AuthHandler{
+	h := &AuthHandler{
 		service:        srv,
 		authMiddleware: authMiddleware,
+		metrics:        metrics,
 	}
+	h.SetRegistrationEnabled(registrationEnabled)
+	return h
+}
+
+// SetRegistrationEnabled opens or closes public signup from the next request on.
+func (h *AuthHandler) SetRegistrationEnabled(enabled bool) {
+	h.registrationEnabled.Store(enabled)
 }
 
 func (h *AuthHandler) Routes() chi.Router {
@@ -41,84 +56,100 @@ func (h *AuthHandler) Routes() chi.Router {
 	return r
 }
 
+// Operations documents Routes for the OpenAPI spec.
+func (h *AuthHandler) Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodPost, Path: "/login", ID: "login", Summary: "Log in and set session cookies", Request: LoginRequest{}, Response: MeResponse{}},
+		{Method: http.MethodPost, Path: "/register", ID: "register", Summary: "Create an account, unless registration is disabled", Request: RegisterRequest{}, Response: MeResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/logout", ID: "logout", Summary: "Clear session cookies", Response: utils.SuccessResponse{}, Auth: true},
+		{Method: http.MethodGet, Path: "/me", ID: "getMe", Summary: "Get the current user", Response: MeResponse{}, Auth: true},
+	}
+}
+
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
-	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.RespondError(w, http.StatusBadRequest, err.Error())
+	req, err := utils.DecodeAndValidate[LoginRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
 	user, err := h.service.ValidateCredentials(r.Context(), req.Email, req.Password)
+	// Outages are not failed logins
+	if err == nil || errors.Is(err, ErrInvalidCredentials) {
+		h.metrics.ObserveLogin(err == nil)
+	}
 	if err != nil {
-		utils.RespondError(w, http.StatusUnauthorized, err.Error())
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
 	accessToken, refreshToken, err := h.authMiddleware.GenerateLoginTokens(user.ID, req.RememberMe)
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
 	h.authMiddleware.SetLoginCookies(w, accessToken, refreshToken, req.RememberMe)
 
-	utils.RespondJSON(w, http.StatusOK, MeResponse{
-		ID:          user.ID,
-		Email:       user.Email,
-		DisplayName: user.DisplayName,
-	})
+	RespondMe(w, r, h.service, http.StatusOK, user.ID)
 }
 
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
-	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.RespondError(w, http.StatusBadRequest, err.Error())
+	// Invite-only deployments disable public signup
+	if !h.registrationEnabled.Load() {
+		utils.RespondProblem(w, r, ErrRegistrationDisabled)
 		return
 	}
 
-	if req.Password != req.PasswordConfirmation {
-		utils.RespondError(w, http.StatusBadRequest, "passwords do not match")
+	req, err := utils.DecodeAndValidate[RegisterRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
 	user, err := h.service.CreateUser(r.Context(), req.DisplayName, req.Email, req.Password)
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
 	accessToken, refreshToken, err := h.authMiddleware.GenerateLoginTokens(user.ID, false)
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
 	h.authMiddleware.SetLoginCookies(w, accessToken, refreshToken, false)
 
-	utils.RespondJSON(w, http.StatusCreated, MeResponse{
-		ID:          user.ID,
-		Email:       user.Email,
-		DisplayName: user.DisplayName,
-	})
+	RespondMe(w, r, h.service, http.StatusCreated, user.ID)
 }
 
 func (h *AuthHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
-		utils.RespondError(w, http.StatusUnauthorized, "unauthenticated")
+		utils.RespondProblem(w, r, middleware.ErrUnauthenticated)
 		return
 	}
 
-	user, err := h.service.GetUserByID(r.Context(), userID)
+	RespondMe(w, r, h.service, http.StatusOK, userID)
+}
+
+// RespondMe renders the user's profile, so every endpoint that signs a user in returns
+// the same MeResponse as /me.
+func RespondMe(w http.ResponseWriter, r *http.Request, service AuthServiceInterface, status int, userID int64) {
+	user, permissions, err := service.GetProfile(r.Context(), userID)
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
-	utils.RespondJSON(w, http.StatusOK, MeResponse{
-		ID:          user.ID,
-		Email:       user.Email,
-		DisplayName: user.DisplayName,
+	utils.RespondJSON(w, status, MeResponse{
+		ID:             user.ID,
+		Email:          user.Email,
+		DisplayName:    user.DisplayName,
+		EmailConfirmed: user.EmailConfirmed,
+		Role:           user.Role.Name,
+		Permissions:    permissions,
 	})
 }
 
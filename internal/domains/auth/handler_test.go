@@ -0,0 +1,177 @@
+package auth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/config"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/metrics"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-web-template/mocks"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type AuthHandlerTestSuite struct {
+	suite.Suite
+	router      *chi.Mux
+	handler     *auth.AuthHandler
+	mockService *mocks.MockAuthServiceInterface
+	metrics     *metrics.Metrics
+}
+
+func (suite *AuthHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{}
+	cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+
+	authMiddleware := middleware.NewAuthMiddleware(cfg, zap.NewNop(), time.Minute, time.Hour, time.Hour)
+	suite.mockService = mocks.NewMockAuthServiceInterface(suite.T())
+	suite.metrics = metrics.New()
+
+	suite.handler = auth.NewAuthHandler(suite.mockService, authMiddleware, suite.metrics, true)
+	suite.router = chi.NewRouter()
+	suite.router.Mount("/auth", suite.handler.Routes())
+}
+
+func TestAuthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthHandlerTestSuite))
+}
+
+func (suite *AuthHandlerTestSuite) register(body auth.RegisterRequest) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *AuthHandlerTestSuite) TestRegister_Success() {
+	suite.mockService.EXPECT().
+		CreateUser(mock.Anything, "Jane", "jane@example.com", "secret123").
+		Return(&user.User{ID: 1, Email: "jane@example.com", DisplayName: "Jane"}, nil).
+		Once()
+	suite.mockService.EXPECT().
+		GetProfile(mock.Anything, int64(1)).
+		Return(&user.User{ID: 1, Email: "jane@example.com", DisplayName: "Jane", Role: &user.Role{Name: "user"}}, []string{}, nil).
+		Once()
+
+	w := suite.register(auth.RegisterRequest{
+		DisplayName:          "Jane",
+		Email:                "Jane@Example.com",
+		Password:             "secret123",
+		PasswordConfirmation: "secret123",
+	})
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Len(w.Result().Cookies(), 2)
+	// A role without permissions is an empty list, not null
+	suite.JSONEq(`{"id":1,"email":"jane@example.com","display_name":"Jane","role":"user","permissions":[]}`, w.Body.String())
+}
+
+func (suite *AuthHandlerTestSuite) TestRegister_DuplicateEmail() {
+	suite.mockService.EXPECT().
+		CreateUser(mock.Anything, mock.Anything, "taken@example.com", mock.Anything).
+		Return(nil, auth.ErrEmailTaken).
+		Once()
+
+	w := suite.register(auth.RegisterRequest{
+		DisplayName:          "Jane",
+		Email:                "taken@example.com",
+		Password:             "secret123",
+		PasswordConfirmation: "secret123",
+	})
+
+	suite.Equal(http.StatusConflict, w.Code)
+
+	var response utils.ProblemDetails
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(apperr.Code("email_taken"), response.Code)
+	suite.Equal([]apperr.FieldError{{Field: "email", Message: "is already registered"}}, response.Errors)
+}
+
+func (suite *AuthHandlerTestSuite) TestLogin_InvalidCredentials() {
+	suite.mockService.EXPECT().
+		ValidateCredentials(mock.Anything, "jane@example.com", "wrong-password").
+		Return(nil, auth.ErrInvalidCredentials).
+		Once()
+
+	payload, _ := json.Marshal(auth.LoginRequest{Email: "jane@example.com", Password: "wrong-password"})
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusUnauthorized, w.Code)
+
+	scrape := httptest.NewRecorder()
+	suite.metrics.Handler().ServeHTTP(scrape, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	suite.Contains(scrape.Body.String(), `auth_login_attempts_total{result="failure"} 1`)
+}
+
+func (suite *AuthHandlerTestSuite) TestRegister_PasswordTooLong() {
+	password := strings.Repeat("é", 37)
+
+	w := suite.register(auth.RegisterRequest{
+		DisplayName:          "Jane",
+		Email:                "jane@example.com",
+		Password:             password,
+		PasswordConfirmation: password,
+	})
+
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+
+	var response utils.ProblemDetails
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal([]apperr.FieldError{{Field: "password", Message: "must be at most 72 bytes"}}, response.Errors)
+}
+
+func (suite *AuthHandlerTestSuite) TestLogin_PasswordTooLong() {
+	payload, _ := json.Marshal(auth.LoginRequest{Email: "jane@example.com", Password: strings.Repeat("a", 73)})
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+}
+
+func (suite *AuthHandlerTestSuite) TestRegister_FollowsRegistrationSwitch() {
+	body := auth.RegisterRequest{
+		DisplayName:          "Jane",
+		Email:                "jane@example.com",
+		Password:             "secret123",
+		PasswordConfirmation: "secret123",
+	}
+
+	suite.handler.SetRegistrationEnabled(false)
+	w := suite.register(body)
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	var response utils.ProblemDetails
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(apperr.Code("registration_disabled"), response.Code)
+
+	// A configuration reload opens signup without rebuilding the routes
+	suite.handler.SetRegistrationEnabled(true)
+	suite.mockService.EXPECT().
+		CreateUser(mock.Anything, "Jane", "jane@example.com", "secret123").
+		Return(nil, auth.ErrEmailTaken).
+		Once()
+
+	w = suite.register(body)
+	suite.Equal(http.StatusConflict, w.Code)
+}
@@ -1,5 +1,11 @@
 package auth
 
+import (
+	"go-web-template/internal/validate"
+	"strings"
+	"time"
+)
+
 type LoginRequest struct {
 	Email      string `json:"email"`
 	Password   string `json:"password"`
@@ -13,8 +19,43 @@ type RegisterRequest struct {
 	PasswordConfirmation string `json:"password_confirmation"`
 }
 
+// MeResponse describes the signed-in user. Permissions are the names granted by Role,
+// for the client to show or hide features; the API checks them again on every request.
 type MeResponse struct {
-	ID          int64  `json:"id"`
-	Email       string `json:"email"`
-	DisplayName string `json:"display_name"`
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	DisplayName    string     `json:"display_name"`
+	EmailConfirmed *time.Time `json:"email_confirmed,omitempty"`
+	Role           string     `json:"role"`
+	Permissions    []string   `json:"permissions"`
+}
+
+func (r *LoginRequest) Normalize() {
+	r.Email = validate.NormalizeEmail(r.Email)
+}
+
+func (r *LoginRequest) Validate() error {
+	var errs validate.Errors
+	errs.Email("email", r.Email)
+	// bcrypt ignores everything past MaxPasswordBytes, so longer input never matches
+	if errs.Required("password", r.Password) {
+		errs.MaxBytes("password", r.Password, validate.MaxPasswordBytes)
+	}
+	return errs.Err()
+}
+
+func (r *RegisterRequest) Normalize() {
+	r.DisplayName = strings.TrimSpace(r.DisplayName)
+	r.Email = validate.NormalizeEmail(r.Email)
+}
+
+func (r *RegisterRequest) Validate() error {
+	var errs validate.Errors
+	if errs.Required("display_name", r.DisplayName) {
+		errs.MaxLength("display_name", r.DisplayName, 255)
+	}
+	errs.Email("email", r.Email)
+	errs.Password("password", r.Password)
+	errs.Matches("password_confirmation", r.PasswordConfirmation, r.Password, "passwords do not match")
+	return errs.Err()
 }
@@ -2,43 +2,90 @@ package auth
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
+	"go-web-template/internal/apperr"
 	"go-web-template/internal/database"
+	"go-web-template/internal/domains/audit"
 	"go-web-template/internal/domains/user"
+	"go-web-template/internal/store"
+	"go-web-template/internal/store/pgerr"
+	"go-web-template/internal/tracing"
 
+	"github.com/jackc/pgx/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidCredentials = apperr.Unauthenticated("invalid credentials").WithCode("invalid_credentials")
+	ErrUserNotFound       = apperr.NotFound("user not found").WithCode("user_not_found")
+	ErrEmailTaken         = emailTaken()
+	ErrRoleNotFound       = apperr.BadRequest("role not found").WithCode("role_not_found")
+	ErrPasswordTooLong    = apperr.Validation(apperr.FieldError{Field: "password", Message: "must be at most 72 bytes"})
+	// ErrRegistrationDisabled is a 404, as if the route didn't exist
+	ErrRegistrationDisabled = apperr.NotFound("registration is disabled").WithCode("registration_disabled")
+)
+
+func emailTaken() *apperr.Error {
+	err := apperr.Conflict("a user with this email already exists").WithCode("email_taken")
+	err.Fields = []apperr.FieldError{{Field: "email", Message: "is already registered"}}
+	return err
+}
+
 type AuthServiceInterface interface {
 	ValidateCredentials(ctx context.Context, email, password string) (*user.User, error)
 	CreateUser(ctx context.Context, displayName, email, password string) (*user.User, error)
+	CreateUserWithRole(ctx context.Context, displayName, email, password string, roleID int64) (*user.User, error)
+	CreateUserInTx(ctx context.Context, q database.Querier, displayName, email, password string, roleID int64) (*user.User, error)
 	GetUserByID(ctx context.Context, userID int64) (*user.User, error)
+	GetProfile(ctx context.Context, userID int64) (*user.User, []string, error)
 }
 
 var _ AuthServiceInterface = (*AuthService)(nil)
 
 type AuthService struct {
-	queries *database.Queries
+	queries database.Querier
+	txm     store.TxManagerInterface
+	auditor audit.Recorder
 }
 
-func NewAuthService(queries *database.Queries) *AuthService {
+func NewAuthService(queries database.Querier, txm store.TxManagerInterface, auditor audit.Recorder) *AuthService {
 	return &AuthService{
 		queries: queries,
+		txm:     txm,
+		auditor: auditor,
 	}
 }
 
 func (s *AuthService) ValidateCredentials(ctx context.Context, email, password string) (*user.User, error) {
+	ctx, span := tracing.Start(ctx, "auth.ValidateCredentials")
+	defer span.End()
+
 	dbUser, err := s.queries.GetUserByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("invalid credentials")
+		if errors.Is(err, pgx.ErrNoRows) {
+			if err := s.recordLoginFailed(ctx, email, nil); err != nil {
+				return nil, err
+			}
+			return nil, ErrInvalidCredentials
 		}
 		return nil, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(dbUser.Password), []byte(password)); err != nil {
-		return nil, errors.New("invalid credentials")
+		if err := s.recordLoginFailed(ctx, email, &dbUser.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.auditor.Record(ctx, s.queries, audit.Event{
+		ActorID:    &dbUser.ID,
+		Action:     audit.ActionLogin,
+		TargetType: audit.TargetUser,
+		TargetID:   &dbUser.ID,
+	}); err != nil {
+		return nil, err
 	}
 
 	return &user.User{
@@ -52,41 +99,90 @@ func (s *AuthService) ValidateCredentials(ctx context.Context, email, password s
 }
 
 func (s *AuthService) CreateUser(ctx context.Context, displayName, email, password string) (*user.User, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	ctx, span := tracing.Start(ctx, "auth.CreateUser")
+	defer span.End()
+
+	defaultRole, err := s.queries.GetDefaultRole(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load default role: %w", err)
+	}
+
+	return s.CreateUserWithRole(ctx, displayName, email, password, defaultRole.ID)
+}
+
+// CreateUserWithRole creates a user with an explicit role, e.g. the one assigned on an invitation.
+func (s *AuthService) CreateUserWithRole(ctx context.Context, displayName, email, password string, roleID int64) (*user.User, error) {
+	ctx, span := tracing.Start(ctx, "auth.CreateUserWithRole")
+	defer span.End()
+
+	var newUser *user.User
+	err := s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		var err error
+		newUser, err = s.CreateUserInTx(ctx, q, displayName, email, password, roleID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	defaultRole, err := s.queries.GetDefaultRole(ctx)
+	return newUser, nil
+}
+
+// CreateUserInTx creates the user and its audit event with q, so both commit or roll
+// back with the caller's transaction.
+func (s *AuthService) CreateUserInTx(ctx context.Context, q database.Querier, displayName, email, password string, roleID int64) (*user.User, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return nil, ErrPasswordTooLong
+	}
 	if err != nil {
-		return nil, errors.New("failed to create user")
+		return nil, err
 	}
 
-	dbUser, err := s.queries.CreateUser(ctx, database.CreateUserParams{
+	dbUser, err := q.CreateUser(ctx, database.CreateUserParams{
 		Email:       email,
 		Password:    string(hashedPassword),
 		DisplayName: displayName,
-		RoleID:      defaultRole.ID,
+		RoleID:      roleID,
 	})
-	if err != nil {
+	switch {
+	case pgerr.IsConstraint(err, "users_email_key"), pgerr.IsConstraint(err, "idx_users_email_lower"):
+		return nil, ErrEmailTaken
+	case pgerr.IsConstraint(err, "users_role_id_fkey"):
+		return nil, ErrRoleNotFound
+	case err != nil:
 		return nil, err
 	}
 
-	return &user.User{
+	newUser := &user.User{
 		ID:          dbUser.ID,
 		Email:       dbUser.Email,
 		DisplayName: dbUser.DisplayName,
 		RoleID:      dbUser.RoleID,
 		CreatedAt:   dbUser.CreatedAt,
 		UpdatedAt:   dbUser.UpdatedAt,
-	}, nil
+	}
+
+	if err := s.auditor.Record(ctx, q, audit.Event{
+		Action:     audit.ActionUserCreated,
+		TargetType: audit.TargetUser,
+		TargetID:   &newUser.ID,
+		After:      newUser,
+	}); err != nil {
+		return nil, err
+	}
+
+	return newUser, nil
 }
 
 func (s *AuthService) GetUserByID(ctx context.Context, userID int64) (*user.User, error) {
+	ctx, span := tracing.Start(ctx, "auth.GetUserByID")
+	defer span.End()
+
 	dbUser, err := s.queries.GetUserByID(ctx, userID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -100,3 +196,59 @@ func (s *AuthService) GetUserByID(ctx context.Context, userID int64) (*user.User
 		UpdatedAt:   dbUser.UpdatedAt,
 	}, nil
 }
+
+// GetProfile loads the user with their role and the names of the role's permissions.
+// The slice is empty rather than nil when the role grants nothing.
+func (s *AuthService) GetProfile(ctx context.Context, userID int64) (*user.User, []string, error) {
+	ctx, span := tracing.Start(ctx, "auth.GetProfile")
+	defer span.End()
+
+	dbUser, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrUserNotFound
+		}
+		return nil, nil, err
+	}
+
+	role, err := s.queries.GetRoleByID(ctx, dbUser.RoleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load role: %w", err)
+	}
+
+	permissions, err := s.queries.ListRolePermissionNames(ctx, role.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load permissions: %w", err)
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &user.User{
+		ID:             dbUser.ID,
+		Email:          dbUser.Email,
+		DisplayName:    dbUser.DisplayName,
+		EmailConfirmed: dbUser.EmailConfirmed,
+		RoleID:         dbUser.RoleID,
+		Role: &user.Role{
+			ID:          role.ID,
+			Name:        role.Name,
+			IsDefault:   role.IsDefault,
+			Description: role.Description,
+			CreatedAt:   role.CreatedAt,
+			UpdatedAt:   role.UpdatedAt,
+		},
+		CreatedAt: dbUser.CreatedAt,
+		UpdatedAt: dbUser.UpdatedAt,
+	}, permissions, nil
+}
+
+// recordLoginFailed logs a rejected login. userID is nil when the email is unknown.
+func (s *AuthService) recordLoginFailed(ctx context.Context, email string, userID *int64) error {
+	return s.auditor.Record(ctx, s.queries, audit.Event{
+		Action:     audit.ActionLoginFailed,
+		TargetType: audit.TargetUser,
+		TargetID:   userID,
+		Metadata:   map[string]any{"email": email},
+	})
+}
@@ -0,0 +1,71 @@
+package auth_test
+
+import (
+	"context"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/tests/integration"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthServiceTestSuite struct {
+	integration.ServiceIntegrationSuite
+}
+
+func TestAuthServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthServiceTestSuite))
+}
+
+func (s *AuthServiceTestSuite) TestCreateUser_DuplicateEmail() {
+	svc := s.TC.Services.AuthService
+
+	_, err := svc.CreateUser(context.Background(), "Jane", "jane@example.com", "secret123")
+	s.Require().NoError(err)
+
+	_, err = svc.CreateUser(context.Background(), "Jane Again", "jane@example.com", "secret123")
+	s.Assert().ErrorIs(err, auth.ErrEmailTaken)
+
+	// The failed attempt rolled back with its audit event
+	events, err := s.TC.Services.AuditService.ListEvents(context.Background(), audit.Filter{
+		Action: audit.ActionUserCreated,
+	}, 1, 10)
+	s.Require().NoError(err)
+	s.Assert().Equal(1, events.Total)
+}
+
+func (s *AuthServiceTestSuite) TestCreateUserWithRole_UnknownRole() {
+	_, err := s.TC.Services.AuthService.CreateUserWithRole(context.Background(), "Jane", "jane@example.com", "secret123", 9999)
+	s.Assert().ErrorIs(err, auth.ErrRoleNotFound)
+}
+
+func (s *AuthServiceTestSuite) TestCreateUser_EmailDiffersOnlyByCase() {
+	svc := s.TC.Services.AuthService
+
+	_, err := svc.CreateUser(context.Background(), "Jane", "jane@example.com", "secret123")
+	s.Require().NoError(err)
+
+	_, err = svc.CreateUser(context.Background(), "Jane Again", "Jane@Example.com", "secret123")
+	s.Assert().ErrorIs(err, auth.ErrEmailTaken)
+
+	_, err = svc.ValidateCredentials(context.Background(), "JANE@example.com", "secret123")
+	s.Assert().NoError(err)
+}
+
+func (s *AuthServiceTestSuite) TestCreateUser_PasswordTooLong() {
+	_, err := s.TC.Services.AuthService.CreateUser(context.Background(), "Jane", "jane@example.com", strings.Repeat("a", 73))
+	s.Assert().ErrorIs(err, auth.ErrPasswordTooLong)
+}
+
+func (s *AuthServiceTestSuite) TestGetProfile_IncludesRolePermissions() {
+	created, err := s.TC.Services.AuthService.CreateUser(context.Background(), "Jane", "jane@example.com", "secret123")
+	s.Require().NoError(err)
+
+	profile, permissions, err := s.TC.Services.AuthService.GetProfile(context.Background(), created.ID)
+	s.Require().NoError(err)
+	s.Assert().Equal("user", profile.Role.Name)
+	s.Assert().NotEmpty(permissions)
+	s.Assert().NotContains(permissions, "system:superadmin")
+}
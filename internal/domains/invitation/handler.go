@@ -0,0 +1,162 @@
+package invitation
+
+import (
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/openapi"
+	"go-web-template/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type InvitationHandler struct {
+	service        InvitationServiceInterface
+	auth           auth.AuthServiceInterface
+	authMiddleware middleware.AuthMiddlewareInterface
+	permissions    middleware.PermissionMiddlewareInterface
+}
+
+func NewInvitationHandler(
+	srv InvitationServiceInterface,
+	authService auth.AuthServiceInterface,
+	authMiddleware middleware.AuthMiddlewareInterface,
+	permissions middleware.PermissionMiddlewareInterface,
+) *InvitationHandler {
+	return &InvitationHandler{
+		service:        srv,
+		auth:           authService,
+		authMiddleware: authMiddleware,
+		permissions:    permissions,
+	}
+}
+
+func (h *InvitationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	// Public routes
+	r.Post("/accept", h.AcceptInvitation)
+
+	// Accepting an organization invitation only needs the invitee's own session
+	r.With(h.authMiddleware.WebClientAuthentication).Post("/join", h.JoinOrganization)
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(h.authMiddleware.WebClientAuthentication)
+		r.Use(h.permissions.Require("invitations:manage"))
+		r.Get("/", h.ListInvitations)
+		r.Post("/", h.CreateInvitation)
+		r.Delete("/{id}", h.RevokeInvitation)
+	})
+
+	return r
+}
+
+// Operations documents Routes for the OpenAPI spec.
+func (h *InvitationHandler) Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodPost, Path: "/accept", ID: "acceptInvitation", Summary: "Accept an invitation and create the account", Request: AcceptInvitationRequest{}, Response: auth.MeResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/join", ID: "joinOrganization", Summary: "Accept an organization invitation with the signed-in account", Request: JoinOrganizationRequest{}, Response: Invitation{}, Auth: true},
+		{Method: http.MethodGet, Path: "/", ID: "listInvitations", Summary: "List invitations", Response: []*Invitation{}, Auth: true, Permission: "invitations:manage"},
+		{Method: http.MethodPost, Path: "/", ID: "createInvitation", Summary: "Invite a user by email", Request: CreateInvitationRequest{}, Response: CreateInvitationResponse{}, Status: http.StatusCreated, Auth: true, Permission: "invitations:manage"},
+		{Method: http.MethodDelete, Path: "/{id}", ID: "revokeInvitation", Summary: "Revoke a pending invitation", Response: utils.SuccessResponse{}, Auth: true, Permission: "invitations:manage"},
+	}
+}
+
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	req, err := utils.DecodeAndValidate[CreateInvitationRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.RespondProblem(w, r, middleware.ErrUnauthenticated)
+		return
+	}
+
+	inv, token, err := h.service.CreateInvitation(r.Context(), userID, req.Email, req.RoleID)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusCreated, CreateInvitationResponse{
+		Invitation: inv,
+		Token:      token,
+	})
+}
+
+func (h *InvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.service.ListInvitations(r.Context())
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, invitations)
+}
+
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid invitation id"))
+		return
+	}
+
+	if err := h.service.RevokeInvitation(r.Context(), id); err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, "Invitation revoked")
+}
+
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	req, err := utils.DecodeAndValidate[AcceptInvitationRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	user, err := h.service.AcceptInvitation(r.Context(), req.Token, req.DisplayName, req.Password)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	accessToken, refreshToken, err := h.authMiddleware.GenerateLoginTokens(user.ID, false)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	h.authMiddleware.SetLoginCookies(w, accessToken, refreshToken, false)
+
+	auth.RespondMe(w, r, h.auth, http.StatusCreated, user.ID)
+}
+
+func (h *InvitationHandler) JoinOrganization(w http.ResponseWriter, r *http.Request) {
+	req, err := utils.DecodeAndValidate[JoinOrganizationRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.RespondProblem(w, r, middleware.ErrUnauthenticated)
+		return
+	}
+
+	inv, err := h.service.JoinOrganization(r.Context(), req.Token, userID)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, inv)
+}
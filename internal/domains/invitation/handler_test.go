@@ -0,0 +1,252 @@
+package invitation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/config"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-web-template/mocks"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type allowAll struct{}
+
+func (allowAll) HasPermission(context.Context, int64, string) (bool, error) { return true, nil }
+
+func (allowAll) HasOrganizationPermission(context.Context, int64, int64, string) (bool, error) {
+	return true, nil
+}
+
+type InvitationHandlerTestSuite struct {
+	suite.Suite
+	router         *chi.Mux
+	mockService    *mocks.MockInvitationServiceInterface
+	mockAuth       *mocks.MockAuthServiceInterface
+	authMiddleware *middleware.AuthMiddleware
+}
+
+func (suite *InvitationHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{}
+	cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+
+	logger := zap.NewNop()
+	suite.authMiddleware = middleware.NewAuthMiddleware(cfg, logger, time.Minute, time.Hour, time.Hour)
+	suite.mockService = mocks.NewMockInvitationServiceInterface(suite.T())
+	suite.mockAuth = mocks.NewMockAuthServiceInterface(suite.T())
+
+	handler := invitation.NewInvitationHandler(
+		suite.mockService,
+		suite.mockAuth,
+		suite.authMiddleware,
+		middleware.NewPermissionMiddleware(allowAll{}, allowAll{}, logger),
+	)
+
+	suite.router = chi.NewRouter()
+	suite.router.Mount("/invitations", handler.Routes())
+}
+
+func TestInvitationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(InvitationHandlerTestSuite))
+}
+
+func (suite *InvitationHandlerTestSuite) authenticatedRequest(method, target string, body any) *http.Request {
+	var buf bytes.Buffer
+	if body != nil {
+		suite.Require().NoError(json.NewEncoder(&buf).Encode(body))
+	}
+
+	accessToken, _, err := suite.authMiddleware.GenerateLoginTokens(7, false)
+	suite.Require().NoError(err)
+
+	req := httptest.NewRequest(method, target, &buf)
+	req.AddCookie(&http.Cookie{Name: "access", Value: accessToken})
+	return req
+}
+
+func (suite *InvitationHandlerTestSuite) TestCreateInvitation_Success() {
+	suite.mockService.EXPECT().
+		CreateInvitation(mock.Anything, int64(7), "new@example.com", int64(2)).
+		Return(&invitation.Invitation{ID: 1, Email: "new@example.com", RoleID: 2, Status: invitation.StatusPending}, "signed-token", nil).
+		Once()
+
+	req := suite.authenticatedRequest(http.MethodPost, "/invitations", invitation.CreateInvitationRequest{
+		Email:  "new@example.com",
+		RoleID: 2,
+	})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response invitation.CreateInvitationResponse
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("signed-token", response.Token)
+	suite.Equal(invitation.StatusPending, response.Invitation.Status)
+}
+
+func (suite *InvitationHandlerTestSuite) TestCreateInvitation_Conflict() {
+	suite.mockService.EXPECT().
+		CreateInvitation(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, "", invitation.ErrInvitationPending).
+		Once()
+
+	req := suite.authenticatedRequest(http.MethodPost, "/invitations", invitation.CreateInvitationRequest{
+		Email:  "pending@example.com",
+		RoleID: 2,
+	})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *InvitationHandlerTestSuite) TestCreateInvitation_Unauthenticated() {
+	req := httptest.NewRequest(http.MethodPost, "/invitations", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (suite *InvitationHandlerTestSuite) TestRevokeInvitation_NotFound() {
+	suite.mockService.EXPECT().
+		RevokeInvitation(mock.Anything, int64(42)).
+		Return(invitation.ErrInvitationNotFound).
+		Once()
+
+	req := suite.authenticatedRequest(http.MethodDelete, "/invitations/42", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *InvitationHandlerTestSuite) TestAcceptInvitation_Success() {
+	suite.mockService.EXPECT().
+		AcceptInvitation(mock.Anything, "signed-token", "New User", "secret123").
+		Return(&user.User{ID: 9, Email: "new@example.com", DisplayName: "New User"}, nil).
+		Once()
+	suite.mockAuth.EXPECT().
+		GetProfile(mock.Anything, int64(9)).
+		Return(&user.User{ID: 9, Email: "new@example.com", DisplayName: "New User", Role: &user.Role{Name: "user"}}, []string{"users:read"}, nil).
+		Once()
+
+	body, _ := json.Marshal(invitation.AcceptInvitationRequest{
+		Token:                "signed-token",
+		DisplayName:          "New User",
+		Password:             "secret123",
+		PasswordConfirmation: "secret123",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/invitations/accept", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Len(w.Result().Cookies(), 2)
+
+	var me auth.MeResponse
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &me))
+	suite.Equal([]string{"users:read"}, me.Permissions)
+}
+
+func (suite *InvitationHandlerTestSuite) TestAcceptInvitation_InvalidToken() {
+	suite.mockService.EXPECT().
+		AcceptInvitation(mock.Anything, "bogus", mock.Anything, mock.Anything).
+		Return(nil, invitation.ErrInvalidInvitation).
+		Once()
+
+	body, _ := json.Marshal(invitation.AcceptInvitationRequest{
+		Token:                "bogus",
+		DisplayName:          "New User",
+		Password:             "secret123",
+		PasswordConfirmation: "secret123",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/invitations/accept", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	var response utils.ProblemDetails
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(invitation.ErrInvalidInvitation.Error(), response.Detail)
+	suite.Equal(apperr.Code("invalid_invitation"), response.Code)
+}
+
+func (suite *InvitationHandlerTestSuite) TestAcceptInvitation_ValidationErrors() {
+	body, _ := json.Marshal(invitation.AcceptInvitationRequest{
+		Token:                "signed-token",
+		Password:             "short",
+		PasswordConfirmation: "different",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/invitations/accept", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+
+	var response utils.ProblemDetails
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(apperr.CodeValidation, response.Code)
+	suite.ElementsMatch([]apperr.FieldError{
+		{Field: "display_name", Message: "is required"},
+		{Field: "password", Message: "must be at least 8 characters"},
+		{Field: "password_confirmation", Message: "passwords do not match"},
+	}, response.Errors)
+}
+
+func (suite *InvitationHandlerTestSuite) TestJoinOrganization_Success() {
+	orgID := int64(3)
+	suite.mockService.EXPECT().
+		JoinOrganization(mock.Anything, "signed-token", int64(7)).
+		Return(&invitation.Invitation{ID: 1, OrganizationID: &orgID, Status: invitation.StatusAccepted}, nil).
+		Once()
+
+	req := suite.authenticatedRequest(http.MethodPost, "/invitations/join", invitation.JoinOrganizationRequest{
+		Token: "signed-token",
+	})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response invitation.Invitation
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(invitation.StatusAccepted, response.Status)
+	suite.Equal(&orgID, response.OrganizationID)
+}
+
+func (suite *InvitationHandlerTestSuite) TestJoinOrganization_Unauthenticated() {
+	body, _ := json.Marshal(invitation.JoinOrganizationRequest{Token: "signed-token"})
+	req := httptest.NewRequest(http.MethodPost, "/invitations/join", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,83 @@
+package invitation
+
+import (
+	"go-web-template/internal/validate"
+	"strings"
+	"time"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+	StatusRevoked  = "revoked"
+	StatusExpired  = "expired"
+)
+
+// Invitation grants RoleID to a new account, or, when OrganizationID is set, makes the
+// invitee a member of that organization with RoleID.
+type Invitation struct {
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	RoleID         int64      `json:"role_id"`
+	OrganizationID *int64     `json:"organization_id,omitempty"`
+	InvitedBy      *int64     `json:"invited_by,omitempty"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type CreateInvitationRequest struct {
+	Email  string `json:"email"`
+	RoleID int64  `json:"role_id"`
+}
+
+type CreateInvitationResponse struct {
+	Invitation *Invitation `json:"invitation"`
+	Token      string      `json:"token"`
+}
+
+type AcceptInvitationRequest struct {
+	Token                string `json:"token"`
+	DisplayName          string `json:"display_name"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"password_confirmation"`
+}
+
+// JoinOrganizationRequest accepts an organization invitation with an existing account.
+type JoinOrganizationRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *CreateInvitationRequest) Normalize() {
+	r.Email = validate.NormalizeEmail(r.Email)
+}
+
+func (r *CreateInvitationRequest) Validate() error {
+	var errs validate.Errors
+	errs.Email("email", r.Email)
+	errs.PositiveID("role_id", r.RoleID)
+	return errs.Err()
+}
+
+func (r *AcceptInvitationRequest) Normalize() {
+	r.DisplayName = strings.TrimSpace(r.DisplayName)
+}
+
+func (r *AcceptInvitationRequest) Validate() error {
+	var errs validate.Errors
+	errs.Required("token", r.Token)
+	if errs.Required("display_name", r.DisplayName) {
+		errs.MaxLength("display_name", r.DisplayName, 255)
+	}
+	errs.Password("password", r.Password)
+	errs.Matches("password_confirmation", r.PasswordConfirmation, r.Password, "passwords do not match")
+	return errs.Err()
+}
+
+func (r *JoinOrganizationRequest) Validate() error {
+	var errs validate.Errors
+	errs.Required("token", r.Token)
+	return errs.Err()
+}
@@ -0,0 +1,573 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/database"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/store"
+	"go-web-template/internal/store/pgerr"
+	"go-web-template/internal/tenant"
+	"go-web-template/internal/tracing"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvitationNotFound = apperr.NotFound("invitation not found").WithCode("invitation_not_found")
+	ErrInvalidInvitation  = apperr.BadRequest("invalid or expired invitation").WithCode("invalid_invitation")
+	ErrInvitationPending  = apperr.Conflict("an invitation is already pending for this email").WithCode("invitation_pending")
+	ErrUserAlreadyExists  = auth.ErrEmailTaken
+	ErrRoleNotFound       = apperr.BadRequest("role not found").WithCode("role_not_found")
+	ErrRoleNotAssignable  = apperr.Forbidden("the role grants permissions the inviter does not hold").WithCode("role_not_assignable")
+	ErrAlreadyMember      = apperr.Conflict("user is already a member of this organization").WithCode("already_member")
+)
+
+// superAdminPermission is never granted by invitation; super-admins are seeded.
+const superAdminPermission = "system:superadmin"
+
+type InvitationServiceInterface interface {
+	CreateInvitation(ctx context.Context, invitedBy int64, email string, roleID int64) (*Invitation, string, error)
+	ListInvitations(ctx context.Context) ([]*Invitation, error)
+	RevokeInvitation(ctx context.Context, id int64) error
+	AcceptInvitation(ctx context.Context, token, displayName, password string) (*user.User, error)
+	CreateOrganizationInvitation(ctx context.Context, invitedBy, organizationID int64, email string, roleID int64) (*Invitation, string, error)
+	ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]*Invitation, error)
+	RevokeOrganizationInvitation(ctx context.Context, organizationID, id int64) error
+	JoinOrganization(ctx context.Context, token string, userID int64) (*Invitation, error)
+}
+
+var _ InvitationServiceInterface = (*InvitationService)(nil)
+
+type invitationClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+type InvitationService struct {
+	queries     database.Querier
+	txm         store.TxManagerInterface
+	authService auth.AuthServiceInterface
+	auditor     audit.Recorder
+	secret      []byte
+	ttl         time.Duration
+}
+
+func NewInvitationService(
+	queries database.Querier,
+	txm store.TxManagerInterface,
+	authService auth.AuthServiceInterface,
+	auditor audit.Recorder,
+	secret string,
+	ttl time.Duration,
+) *InvitationService {
+	return &InvitationService{
+		queries:     queries,
+		txm:         txm,
+		authService: authService,
+		auditor:     auditor,
+		secret:      []byte(secret),
+		ttl:         ttl,
+	}
+}
+
+func toInvitationModel(dbInvitation database.Invitation) *Invitation {
+	inv := &Invitation{
+		ID:             dbInvitation.ID,
+		Email:          dbInvitation.Email,
+		RoleID:         dbInvitation.RoleID,
+		OrganizationID: dbInvitation.OrganizationID,
+		InvitedBy:      dbInvitation.InvitedBy,
+		ExpiresAt:      dbInvitation.ExpiresAt,
+		AcceptedAt:     dbInvitation.AcceptedAt,
+		RevokedAt:      dbInvitation.RevokedAt,
+		CreatedAt:      dbInvitation.CreatedAt,
+	}
+
+	switch {
+	case inv.AcceptedAt != nil:
+		inv.Status = StatusAccepted
+	case inv.RevokedAt != nil:
+		inv.Status = StatusRevoked
+	case time.Now().After(inv.ExpiresAt):
+		inv.Status = StatusExpired
+	default:
+		inv.Status = StatusPending
+	}
+
+	return inv
+}
+
+func (s *InvitationService) CreateInvitation(ctx context.Context, invitedBy int64, email string, roleID int64) (*Invitation, string, error) {
+	ctx, span := tracing.Start(ctx, "invitation.CreateInvitation")
+	defer span.End()
+
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if _, err := s.queries.GetUserByEmail(ctx, email); err == nil {
+		return nil, "", ErrUserAlreadyExists
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", err
+	}
+
+	if err := s.checkAssignable(ctx, invitedBy, roleID); err != nil {
+		return nil, "", err
+	}
+
+	return s.create(ctx, invitedBy, nil, email, roleID, nil)
+}
+
+// CreateOrganizationInvitation invites email to join an organization with roleID. The
+// invitee may already have an account, in which case they accept with JoinOrganization.
+func (s *InvitationService) CreateOrganizationInvitation(ctx context.Context, invitedBy, organizationID int64, email string, roleID int64) (*Invitation, string, error) {
+	ctx, span := tracing.Start(ctx, "invitation.CreateOrganizationInvitation")
+	defer span.End()
+
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	var existingID int64
+	if existing, err := s.queries.GetUserByEmail(ctx, email); err == nil {
+		existingID = existing.ID
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", err
+	}
+
+	// Members and roles are checked in the organization's transaction, which is the only
+	// place its rows are visible
+	ctx = tenant.WithOrganizationID(ctx, organizationID)
+	return s.create(ctx, invitedBy, &organizationID, email, roleID, func(q database.Querier) error {
+		if existingID != 0 {
+			_, err := q.GetOrganizationMember(ctx, database.GetOrganizationMemberParams{
+				OrganizationID: organizationID,
+				UserID:         existingID,
+			})
+			if err == nil {
+				return ErrAlreadyMember
+			} else if !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+		}
+		return checkOrganizationAssignable(ctx, q, invitedBy, organizationID, roleID)
+	})
+}
+
+// create stores an invitation once the caller has checked the role may be handed out.
+// check, if not nil, runs first in the same transaction.
+func (s *InvitationService) create(ctx context.Context, invitedBy int64, organizationID *int64, email string, roleID int64, check func(q database.Querier) error) (*Invitation, string, error) {
+	var inviter *int64
+	if invitedBy != 0 {
+		inviter = &invitedBy
+	}
+
+	var inv *Invitation
+	var token string
+	err := s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		if check != nil {
+			if err := check(q); err != nil {
+				return err
+			}
+		}
+
+		existing, err := q.GetPendingInvitationByEmail(ctx, database.GetPendingInvitationByEmailParams{
+			Email:          email,
+			OrganizationID: organizationID,
+		})
+		if err == nil {
+			// An expired invitation should not block a fresh one
+			if time.Now().Before(existing.ExpiresAt) {
+				return ErrInvitationPending
+			}
+			if _, err := q.RevokeInvitation(ctx, database.RevokeInvitationParams{
+				ID:             existing.ID,
+				OrganizationID: organizationID,
+			}); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		dbInvitation, err := q.CreateInvitation(ctx, database.CreateInvitationParams{
+			Email:          email,
+			RoleID:         roleID,
+			InvitedBy:      inviter,
+			ExpiresAt:      time.Now().Add(s.ttl),
+			OrganizationID: organizationID,
+		})
+		switch {
+		case pgerr.IsConstraint(err, "idx_invitations_pending_email"):
+			// Lost a race with a concurrent invitation for the same email
+			return ErrInvitationPending
+		case pgerr.IsConstraint(err, "invitations_role_id_fkey"):
+			return ErrRoleNotFound
+		case err != nil:
+			return err
+		}
+
+		token, err = s.signToken(dbInvitation)
+		if err != nil {
+			return err
+		}
+
+		inv = toInvitationModel(dbInvitation)
+		return s.auditor.Record(ctx, q, audit.Event{
+			Action:     audit.ActionInvitationCreated,
+			TargetType: audit.TargetInvitation,
+			TargetID:   &inv.ID,
+			After:      inv,
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return inv, token, nil
+}
+
+func (s *InvitationService) ListInvitations(ctx context.Context) ([]*Invitation, error) {
+	ctx, span := tracing.Start(ctx, "invitation.ListInvitations")
+	defer span.End()
+
+	dbInvitations, err := s.queries.ListInvitations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*Invitation, len(dbInvitations))
+	for i, dbInvitation := range dbInvitations {
+		invitations[i] = toInvitationModel(dbInvitation)
+	}
+
+	return invitations, nil
+}
+
+func (s *InvitationService) ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]*Invitation, error) {
+	ctx, span := tracing.Start(ctx, "invitation.ListOrganizationInvitations")
+	defer span.End()
+
+	var dbInvitations []database.Invitation
+	err := s.txm.WithTx(tenant.WithOrganizationID(ctx, organizationID), func(ctx context.Context, q database.Querier) error {
+		var err error
+		dbInvitations, err = q.ListOrganizationInvitations(ctx, organizationID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*Invitation, len(dbInvitations))
+	for i, dbInvitation := range dbInvitations {
+		invitations[i] = toInvitationModel(dbInvitation)
+	}
+
+	return invitations, nil
+}
+
+func (s *InvitationService) RevokeInvitation(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "invitation.RevokeInvitation")
+	defer span.End()
+
+	return s.revoke(ctx, nil, id)
+}
+
+func (s *InvitationService) RevokeOrganizationInvitation(ctx context.Context, organizationID, id int64) error {
+	ctx, span := tracing.Start(ctx, "invitation.RevokeOrganizationInvitation")
+	defer span.End()
+
+	return s.revoke(tenant.WithOrganizationID(ctx, organizationID), &organizationID, id)
+}
+
+// revoke only matches invitations to organizationID, or global ones when it is nil, so
+// an organization can't revoke another's invitations.
+func (s *InvitationService) revoke(ctx context.Context, organizationID *int64, id int64) error {
+	return s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		affected, err := q.RevokeInvitation(ctx, database.RevokeInvitationParams{
+			ID:             id,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrInvitationNotFound
+		}
+
+		return s.auditor.Record(ctx, q, audit.Event{
+			Action:     audit.ActionInvitationRevoked,
+			TargetType: audit.TargetInvitation,
+			TargetID:   &id,
+		})
+	})
+}
+
+func (s *InvitationService) AcceptInvitation(ctx context.Context, token, displayName, password string) (*user.User, error) {
+	ctx, span := tracing.Start(ctx, "invitation.AcceptInvitation")
+	defer span.End()
+
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	// The row lock makes a concurrent accept or revoke of the same invitation wait, and
+	// the account, the accepted mark and the audit event commit together. The invitee
+	// isn't in the organization yet, so the signed token stands in for a tenant
+	var newUser *user.User
+	err = s.txm.WithTx(tenant.WithBypass(ctx), func(ctx context.Context, q database.Querier) error {
+		dbInvitation, err := s.lockPending(ctx, q, claims)
+		if err != nil {
+			return err
+		}
+
+		// An organization invitation grants its role in the organization only; the
+		// account itself gets the default role
+		roleID := dbInvitation.RoleID
+		if dbInvitation.OrganizationID != nil {
+			defaultRole, err := q.GetDefaultRole(ctx)
+			if err != nil {
+				return err
+			}
+			roleID = defaultRole.ID
+		}
+
+		newUser, err = s.authService.CreateUserInTx(ctx, q, displayName, dbInvitation.Email, password, roleID)
+		if err != nil {
+			return err
+		}
+
+		return s.markAccepted(ctx, q, dbInvitation, newUser.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newUser, nil
+}
+
+// JoinOrganization accepts an organization invitation for userID, an existing account
+// whose email the invitation was sent to.
+func (s *InvitationService) JoinOrganization(ctx context.Context, token string, userID int64) (*Invitation, error) {
+	ctx, span := tracing.Start(ctx, "invitation.JoinOrganization")
+	defer span.End()
+
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	var inv *Invitation
+	err = s.txm.WithTx(tenant.WithBypass(ctx), func(ctx context.Context, q database.Querier) error {
+		dbInvitation, err := s.lockPending(ctx, q, claims)
+		if err != nil {
+			return err
+		}
+		if dbInvitation.OrganizationID == nil {
+			return ErrInvalidInvitation
+		}
+
+		dbUser, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if dbUser.Email != dbInvitation.Email {
+			return ErrInvalidInvitation
+		}
+
+		if err := s.markAccepted(ctx, q, dbInvitation, userID); err != nil {
+			return err
+		}
+
+		inv = toInvitationModel(dbInvitation)
+		inv.Status = StatusAccepted
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+// lockPending loads the invitation claims refers to and locks its row, so a concurrent
+// accept or revoke of it waits. Anything but a pending invitation to the claimed email
+// is ErrInvalidInvitation.
+func (s *InvitationService) lockPending(ctx context.Context, q database.Querier, claims *invitationClaims) (database.Invitation, error) {
+	invitationID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return database.Invitation{}, ErrInvalidInvitation
+	}
+
+	dbInvitation, err := q.GetInvitationByIDForUpdate(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.Invitation{}, ErrInvalidInvitation
+		}
+		return database.Invitation{}, err
+	}
+
+	if toInvitationModel(dbInvitation).Status != StatusPending || dbInvitation.Email != claims.Email {
+		return database.Invitation{}, ErrInvalidInvitation
+	}
+	return dbInvitation, nil
+}
+
+// markAccepted marks the locked invitation accepted by userID, adds them to its
+// organization if it has one, and records both.
+func (s *InvitationService) markAccepted(ctx context.Context, q database.Querier, dbInvitation database.Invitation, userID int64) error {
+	if dbInvitation.OrganizationID != nil {
+		_, err := q.AddOrganizationMember(ctx, database.AddOrganizationMemberParams{
+			OrganizationID: *dbInvitation.OrganizationID,
+			UserID:         userID,
+			RoleID:         dbInvitation.RoleID,
+		})
+		if pgerr.IsConstraint(err, "organization_members_pkey") {
+			return ErrAlreadyMember
+		} else if err != nil {
+			return err
+		}
+
+		if err := s.auditor.Record(ctx, q, audit.Event{
+			ActorID:    &userID,
+			Action:     audit.ActionMemberAdded,
+			TargetType: audit.TargetUser,
+			TargetID:   &userID,
+			After:      map[string]any{"role_id": dbInvitation.RoleID},
+			Metadata:   map[string]any{"organization_id": *dbInvitation.OrganizationID, "invitation_id": dbInvitation.ID},
+		}); err != nil {
+			return err
+		}
+	}
+
+	affected, err := q.MarkInvitationAccepted(ctx, dbInvitation.ID)
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return ErrInvalidInvitation
+	}
+
+	metadata := map[string]any{"user_id": userID, "role_id": dbInvitation.RoleID}
+	if dbInvitation.OrganizationID != nil {
+		metadata["organization_id"] = *dbInvitation.OrganizationID
+	}
+	return s.auditor.Record(ctx, q, audit.Event{
+		ActorID:    &userID,
+		Action:     audit.ActionInvitationAccepted,
+		TargetType: audit.TargetInvitation,
+		TargetID:   &dbInvitation.ID,
+		Metadata:   metadata,
+	})
+}
+
+// checkAssignable rejects roles an inviter may not hand out: any role carrying
+// system:superadmin, and roles with a permission the inviter's own role lacks. An
+// invitedBy of 0 is the system itself, which is only held to the first rule.
+func (s *InvitationService) checkAssignable(ctx context.Context, invitedBy, roleID int64) error {
+	if _, err := s.queries.GetRoleByID(ctx, roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+
+	permissions, err := s.queries.ListRolePermissionNames(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if permission == superAdminPermission {
+			return ErrRoleNotAssignable
+		}
+		if invitedBy == 0 {
+			continue
+		}
+
+		held, err := s.queries.UserHasPermission(ctx, database.UserHasPermissionParams{
+			UserID:     invitedBy,
+			Permission: permission,
+		})
+		if err != nil {
+			return err
+		}
+		if !held {
+			return ErrRoleNotAssignable
+		}
+	}
+	return nil
+}
+
+// checkOrganizationAssignable rejects roles that can't be given to members of
+// organizationID: global roles, any role carrying system:superadmin, and roles with a
+// permission the inviter lacks in the organization.
+func checkOrganizationAssignable(ctx context.Context, q database.Querier, invitedBy, organizationID, roleID int64) error {
+	if _, err := q.GetRoleByID(ctx, roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+
+	var actor *int64
+	if invitedBy != 0 {
+		actor = &invitedBy
+	}
+	assignable, err := q.CanAssignOrganizationRole(ctx, database.CanAssignOrganizationRoleParams{
+		RoleID:         roleID,
+		ActorID:        actor,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return err
+	}
+	if !assignable {
+		return ErrRoleNotAssignable
+	}
+	return nil
+}
+
+func (s *InvitationService) signToken(inv database.Invitation) (string, error) {
+	if len(s.secret) == 0 {
+		return "", fmt.Errorf("invitation secret is not configured")
+	}
+
+	claims := invitationClaims{
+		Email: inv.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(inv.ID, 10),
+			ExpiresAt: jwt.NewNumericDate(inv.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "go-web-template",
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.secret)
+}
+
+func (s *InvitationService) parseToken(tokenString string) (*invitationClaims, error) {
+	if len(s.secret) == 0 {
+		return nil, fmt.Errorf("invitation secret is not configured")
+	}
+
+	claims := &invitationClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
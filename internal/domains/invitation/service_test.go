@@ -0,0 +1,135 @@
+package invitation_test
+
+import (
+	"context"
+	"go-web-template/internal/database"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/tests/integration"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationServiceTestSuite struct {
+	integration.ServiceIntegrationSuite
+}
+
+func TestInvitationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(InvitationServiceTestSuite))
+}
+
+func (s *InvitationServiceTestSuite) TestCreateInvitation_RejectsSuperAdminRole() {
+	admin := s.seedUser("admin@example.com", "super-admin")
+
+	_, _, err := s.TC.Services.InvitationService.CreateInvitation(context.Background(), admin, "new@example.com", s.roleID("super-admin"))
+	s.Assert().ErrorIs(err, invitation.ErrRoleNotAssignable)
+}
+
+func (s *InvitationServiceTestSuite) TestCreateInvitation_RejectsRoleBeyondInviter() {
+	// A regular user given invitations:manage still can't hand out admin
+	inviter := s.seedUser("inviter@example.com", "user")
+
+	_, _, err := s.TC.Services.InvitationService.CreateInvitation(context.Background(), inviter, "new@example.com", s.roleID("admin"))
+	s.Assert().ErrorIs(err, invitation.ErrRoleNotAssignable)
+
+	invitations, err := s.TC.Services.InvitationService.ListInvitations(context.Background())
+	s.Require().NoError(err)
+	s.Assert().Empty(invitations)
+}
+
+func (s *InvitationServiceTestSuite) TestCreateInvitation_AllowsRoleWithinInviter() {
+	inviter := s.seedUser("admin@example.com", "admin")
+
+	inv, token, err := s.TC.Services.InvitationService.CreateInvitation(context.Background(), inviter, "new@example.com", s.roleID("user"))
+	s.Require().NoError(err)
+	s.Assert().NotEmpty(token)
+	s.Assert().Equal(invitation.StatusPending, inv.Status)
+}
+
+func (s *InvitationServiceTestSuite) TestAcceptInvitation_OnlyOnce() {
+	svc := s.TC.Services.InvitationService
+	inviter := s.seedUser("admin@example.com", "admin")
+	_, token, err := svc.CreateInvitation(context.Background(), inviter, "new@example.com", s.roleID("user"))
+	s.Require().NoError(err)
+
+	newUser, err := svc.AcceptInvitation(context.Background(), token, "New", "secret123")
+	s.Require().NoError(err)
+	s.Assert().Equal("new@example.com", newUser.Email)
+
+	_, err = svc.AcceptInvitation(context.Background(), token, "New Again", "secret123")
+	s.Assert().ErrorIs(err, invitation.ErrInvalidInvitation)
+
+	events, err := s.TC.Services.AuditService.ListEvents(context.Background(), audit.Filter{
+		Action: audit.ActionInvitationAccepted,
+	}, 1, 10)
+	s.Require().NoError(err)
+	s.Assert().Equal(1, events.Total)
+}
+
+func (s *InvitationServiceTestSuite) TestAcceptInvitation_Revoked() {
+	svc := s.TC.Services.InvitationService
+	inviter := s.seedUser("admin@example.com", "admin")
+	inv, token, err := svc.CreateInvitation(context.Background(), inviter, "new@example.com", s.roleID("user"))
+	s.Require().NoError(err)
+	s.Require().NoError(svc.RevokeInvitation(context.Background(), inv.ID))
+
+	_, err = svc.AcceptInvitation(context.Background(), token, "New", "secret123")
+	s.Assert().ErrorIs(err, invitation.ErrInvalidInvitation)
+
+	_, err = s.TC.Queries.GetUserByEmail(context.Background(), "new@example.com")
+	s.Assert().Error(err)
+}
+
+func (s *InvitationServiceTestSuite) TestAcceptInvitation_OrganizationRoleStaysInOrganization() {
+	owner := s.seedUser("owner@example.com", "user")
+	org, err := s.TC.Services.OrganizationService.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	svc := s.TC.Services.InvitationService
+	_, token, err := svc.CreateOrganizationInvitation(context.Background(), owner, org.ID, "new@example.com", s.roleID("admin"))
+	s.Require().NoError(err)
+
+	newUser, err := svc.AcceptInvitation(context.Background(), token, "New", "secret123")
+	s.Require().NoError(err)
+
+	// The account gets the default role; admin only applies inside the organization
+	dbUser, err := s.TC.Queries.GetUserByID(context.Background(), newUser.ID)
+	s.Require().NoError(err)
+	s.Assert().Equal(s.roleID("user"), dbUser.RoleID)
+
+	allowed, err := s.TC.Services.OrganizationService.HasOrganizationPermission(context.Background(), org.ID, newUser.ID, "users:write")
+	s.Require().NoError(err)
+	s.Assert().True(allowed)
+}
+
+func (s *InvitationServiceTestSuite) TestJoinOrganization_RejectsOtherAccount() {
+	owner := s.seedUser("owner@example.com", "user")
+	other := s.seedUser("other@example.com", "user")
+	org, err := s.TC.Services.OrganizationService.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	svc := s.TC.Services.InvitationService
+	_, token, err := svc.CreateOrganizationInvitation(context.Background(), owner, org.ID, "invitee@example.com", s.roleID("user"))
+	s.Require().NoError(err)
+
+	_, err = svc.JoinOrganization(context.Background(), token, other)
+	s.Assert().ErrorIs(err, invitation.ErrInvalidInvitation)
+}
+
+func (s *InvitationServiceTestSuite) roleID(name string) int64 {
+	role, err := s.TC.Queries.GetRoleByName(context.Background(), name)
+	s.Require().NoError(err)
+	return role.ID
+}
+
+func (s *InvitationServiceTestSuite) seedUser(email, role string) int64 {
+	u, err := s.TC.Queries.CreateUser(context.Background(), database.CreateUserParams{
+		Email:       email,
+		Password:    "hashed",
+		DisplayName: email,
+		RoleID:      s.roleID(role),
+	})
+	s.Require().NoError(err)
+	return u.ID
+}
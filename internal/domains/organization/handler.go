@@ -0,0 +1,240 @@
+package organization
+
+import (
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/openapi"
+	"go-web-template/internal/tenant"
+	"go-web-template/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type OrganizationHandler struct {
+	service     OrganizationServiceInterface
+	invitations invitation.InvitationServiceInterface
+	tenants     middleware.TenantMiddlewareInterface
+	permissions middleware.PermissionMiddlewareInterface
+}
+
+func NewOrganizationHandler(
+	srv OrganizationServiceInterface,
+	invitations invitation.InvitationServiceInterface,
+	tenants middleware.TenantMiddlewareInterface,
+	permissions middleware.PermissionMiddlewareInterface,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		service:     srv,
+		invitations: invitations,
+		tenants:     tenants,
+		permissions: permissions,
+	}
+}
+
+// Routes expects to be mounted behind WebClientAuthentication.
+func (h *OrganizationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", h.ListOrganizations)
+	r.Post("/", h.CreateOrganization)
+
+	// Tenant-scoped routes, permissions are checked against the caller's role in {orgID}
+	r.Route("/{"+middleware.OrganizationPathParam+"}", func(r chi.Router) {
+		r.Use(h.tenants.ResolveOrganization)
+		r.Get("/", h.GetOrganization)
+
+		r.With(h.permissions.Require("users:read")).Get("/members", h.ListMembers)
+		r.With(h.permissions.Require("users:write")).Put("/members/{userID}", h.UpdateMemberRole)
+		r.With(h.permissions.Require("users:delete")).Delete("/members/{userID}", h.RemoveMember)
+
+		// Members join by accepting an invitation, see POST /invitations/join
+		r.Group(func(r chi.Router) {
+			r.Use(h.permissions.Require("invitations:manage"))
+			r.Get("/invitations", h.ListInvitations)
+			r.Post("/invitations", h.CreateInvitation)
+			r.Delete("/invitations/{id}", h.RevokeInvitation)
+		})
+	})
+
+	return r
+}
+
+// Operations documents Routes for the OpenAPI spec. Permissions on tenant-scoped routes
+// are checked against the caller's role in the organization.
+func (h *OrganizationHandler) Operations() []openapi.Operation {
+	orgPath := "/{" + middleware.OrganizationPathParam + "}"
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/", ID: "listOrganizations", Summary: "List the caller's organizations", Response: []*Organization{}, Auth: true},
+		{Method: http.MethodPost, Path: "/", ID: "createOrganization", Summary: "Create an organization owned by the caller", Request: CreateOrganizationRequest{}, Response: Organization{}, Status: http.StatusCreated, Auth: true},
+		{Method: http.MethodGet, Path: orgPath, ID: "getOrganization", Summary: "Get an organization", Response: Organization{}, Auth: true},
+		{Method: http.MethodGet, Path: orgPath + "/members", ID: "listMembers", Summary: "List members", Response: []*Member{}, Auth: true, Permission: "users:read"},
+		{Method: http.MethodPut, Path: orgPath + "/members/{userID}", ID: "updateMemberRole", Summary: "Change a member's role", Request: UpdateMemberRoleRequest{}, Response: utils.SuccessResponse{}, Auth: true, Permission: "users:write"},
+		{Method: http.MethodDelete, Path: orgPath + "/members/{userID}", ID: "removeMember", Summary: "Remove a member", Response: utils.SuccessResponse{}, Auth: true, Permission: "users:delete"},
+		{Method: http.MethodGet, Path: orgPath + "/invitations", ID: "listOrganizationInvitations", Summary: "List invitations to the organization", Response: []*invitation.Invitation{}, Auth: true, Permission: "invitations:manage"},
+		{Method: http.MethodPost, Path: orgPath + "/invitations", ID: "createOrganizationInvitation", Summary: "Invite a user by email to join the organization", Request: invitation.CreateInvitationRequest{}, Response: invitation.CreateInvitationResponse{}, Status: http.StatusCreated, Auth: true, Permission: "invitations:manage"},
+		{Method: http.MethodDelete, Path: orgPath + "/invitations/{id}", ID: "revokeOrganizationInvitation", Summary: "Revoke a pending invitation to the organization", Response: utils.SuccessResponse{}, Auth: true, Permission: "invitations:manage"},
+	}
+}
+
+func (h *OrganizationHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.RespondProblem(w, r, middleware.ErrUnauthenticated)
+		return
+	}
+
+	orgs, err := h.service.ListUserOrganizations(r.Context(), userID)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, orgs)
+}
+
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	req, err := utils.DecodeAndValidate[CreateOrganizationRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.RespondProblem(w, r, middleware.ErrUnauthenticated)
+		return
+	}
+
+	org, err := h.service.CreateOrganization(r.Context(), userID, req.Name, req.Slug)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusCreated, org)
+}
+
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	org, err := h.service.GetCurrentOrganization(r.Context())
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := h.service.ListMembers(r.Context())
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, members)
+}
+
+func (h *OrganizationHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid user id"))
+		return
+	}
+
+	req, err := utils.DecodeAndValidate[UpdateMemberRoleRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	if err := h.service.UpdateMemberRole(r.Context(), userID, req.RoleID); err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, "Member updated")
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid user id"))
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), userID); err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, "Member removed")
+}
+
+func (h *OrganizationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := tenant.OrganizationID(r.Context())
+	if !ok {
+		utils.RespondProblem(w, r, ErrNoOrganization)
+		return
+	}
+
+	invitations, err := h.invitations.ListOrganizationInvitations(r.Context(), organizationID)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, invitations)
+}
+
+func (h *OrganizationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	req, err := utils.DecodeAndValidate[invitation.CreateInvitationRequest](w, r)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.RespondProblem(w, r, middleware.ErrUnauthenticated)
+		return
+	}
+	organizationID, ok := tenant.OrganizationID(r.Context())
+	if !ok {
+		utils.RespondProblem(w, r, ErrNoOrganization)
+		return
+	}
+
+	inv, token, err := h.invitations.CreateOrganizationInvitation(r.Context(), userID, organizationID, req.Email, req.RoleID)
+	if err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusCreated, invitation.CreateInvitationResponse{
+		Invitation: inv,
+		Token:      token,
+	})
+}
+
+func (h *OrganizationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondProblem(w, r, apperr.BadRequest("invalid invitation id"))
+		return
+	}
+
+	organizationID, ok := tenant.OrganizationID(r.Context())
+	if !ok {
+		utils.RespondProblem(w, r, ErrNoOrganization)
+		return
+	}
+
+	if err := h.invitations.RevokeOrganizationInvitation(r.Context(), organizationID, id); err != nil {
+		utils.RespondProblem(w, r, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, "Invitation revoked")
+}
@@ -0,0 +1,56 @@
+package organization
+
+import (
+	"go-web-template/internal/validate"
+	"strings"
+	"time"
+)
+
+type Organization struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	RoleID    *int64    `json:"role_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Member struct {
+	UserID      int64     `json:"user_id"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name"`
+	RoleID      int64     `json:"role_id"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type UpdateMemberRoleRequest struct {
+	RoleID int64 `json:"role_id"`
+}
+
+func (r *CreateOrganizationRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Slug = strings.ToLower(strings.TrimSpace(r.Slug))
+}
+
+func (r *CreateOrganizationRequest) Validate() error {
+	var errs validate.Errors
+	if errs.Required("name", r.Name) {
+		errs.MaxLength("name", r.Name, 255)
+	}
+	if errs.Required("slug", r.Slug) {
+		errs.MaxLength("slug", r.Slug, 100)
+		errs.Slug("slug", r.Slug)
+	}
+	return errs.Err()
+}
+
+func (r *UpdateMemberRoleRequest) Validate() error {
+	var errs validate.Errors
+	errs.PositiveID("role_id", r.RoleID)
+	return errs.Err()
+}
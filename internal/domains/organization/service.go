@@ -0,0 +1,426 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/database"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/reqctx"
+	"go-web-template/internal/store"
+	"go-web-template/internal/store/pgerr"
+	"go-web-template/internal/tenant"
+	"go-web-template/internal/tracing"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OwnerRole is assigned to the user who creates an organization.
+const OwnerRole = "admin"
+
+var (
+	ErrNoOrganization       = apperr.BadRequest("no active organization").WithCode("organization_required")
+	ErrOrganizationNotFound = apperr.NotFound("organization not found").WithCode("organization_not_found")
+	ErrSlugTaken            = apperr.Conflict("organization slug is already taken").WithCode("slug_taken")
+	ErrMemberNotFound       = apperr.NotFound("member not found").WithCode("member_not_found")
+	ErrRoleNotFound         = apperr.BadRequest("role not found").WithCode("role_not_found")
+	ErrRoleNotAssignable    = apperr.Forbidden("the role cannot be given to organization members").WithCode("role_not_assignable")
+	ErrMemberNotManageable  = apperr.Forbidden("the member's role has permissions you lack").WithCode("member_not_manageable")
+	ErrLastOwner            = apperr.Conflict("the organization's last admin cannot be demoted or removed").WithCode("last_owner")
+)
+
+type OrganizationServiceInterface interface {
+	CreateOrganization(ctx context.Context, ownerID int64, name, slug string) (*Organization, error)
+	ListUserOrganizations(ctx context.Context, userID int64) ([]*Organization, error)
+	GetCurrentOrganization(ctx context.Context) (*Organization, error)
+	ListMembers(ctx context.Context) ([]*Member, error)
+	UpdateMemberRole(ctx context.Context, userID, roleID int64) error
+	RemoveMember(ctx context.Context, userID int64) error
+	IsMember(ctx context.Context, organizationID, userID int64) (bool, error)
+	HasOrganizationPermission(ctx context.Context, organizationID, userID int64, permission string) (bool, error)
+}
+
+var _ OrganizationServiceInterface = (*OrganizationService)(nil)
+
+type OrganizationService struct {
+	queries database.Querier
+	txm     store.TxManagerInterface
+	auditor audit.Recorder
+}
+
+func NewOrganizationService(queries database.Querier, txm store.TxManagerInterface, auditor audit.Recorder) *OrganizationService {
+	return &OrganizationService{
+		queries: queries,
+		txm:     txm,
+		auditor: auditor,
+	}
+}
+
+func toOrganizationModel(dbOrg database.Organization) *Organization {
+	return &Organization{
+		ID:        dbOrg.ID,
+		Name:      dbOrg.Name,
+		Slug:      dbOrg.Slug,
+		CreatedAt: dbOrg.CreatedAt,
+		UpdatedAt: dbOrg.UpdatedAt,
+	}
+}
+
+// currentOrganization returns the tenant resolved by the tenant middleware.
+func currentOrganization(ctx context.Context) (int64, error) {
+	organizationID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return 0, ErrNoOrganization
+	}
+	return organizationID, nil
+}
+
+func (s *OrganizationService) CreateOrganization(ctx context.Context, ownerID int64, name, slug string) (*Organization, error) {
+	ctx, span := tracing.Start(ctx, "organization.CreateOrganization")
+	defer span.End()
+
+	slug = strings.ToLower(strings.TrimSpace(slug))
+
+	ownerRole, err := s.queries.GetRoleByName(ctx, OwnerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	// The new organization isn't a tenant anyone is scoped to yet
+	var org *Organization
+	err = s.txm.WithTx(tenant.WithBypass(ctx), func(ctx context.Context, q database.Querier) error {
+		dbOrg, err := q.CreateOrganization(ctx, database.CreateOrganizationParams{
+			Name: strings.TrimSpace(name),
+			Slug: slug,
+		})
+		switch {
+		case pgerr.IsConstraint(err, "organizations_slug_key"):
+			return ErrSlugTaken
+		case err != nil:
+			return err
+		}
+
+		_, err = q.AddOrganizationMember(ctx, database.AddOrganizationMemberParams{
+			OrganizationID: dbOrg.ID,
+			UserID:         ownerID,
+			RoleID:         ownerRole.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		org = toOrganizationModel(dbOrg)
+		return s.auditor.Record(ctx, q, audit.Event{
+			Action:     audit.ActionOrganizationCreated,
+			TargetType: audit.TargetOrganization,
+			TargetID:   &org.ID,
+			After:      org,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	org.RoleID = &ownerRole.ID
+	return org, nil
+}
+
+func (s *OrganizationService) ListUserOrganizations(ctx context.Context, userID int64) ([]*Organization, error) {
+	ctx, span := tracing.Start(ctx, "organization.ListUserOrganizations")
+	defer span.End()
+
+	// The user's organizations are by definition more than one tenant
+	var rows []database.ListOrganizationsForUserRow
+	err := s.txm.WithTx(tenant.WithBypass(ctx), func(ctx context.Context, q database.Querier) error {
+		var err error
+		rows, err = q.ListOrganizationsForUser(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]*Organization, len(rows))
+	for i, row := range rows {
+		orgs[i] = &Organization{
+			ID:        row.ID,
+			Name:      row.Name,
+			Slug:      row.Slug,
+			RoleID:    &row.RoleID,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		}
+	}
+
+	return orgs, nil
+}
+
+func (s *OrganizationService) GetCurrentOrganization(ctx context.Context) (*Organization, error) {
+	ctx, span := tracing.Start(ctx, "organization.GetCurrentOrganization")
+	defer span.End()
+
+	organizationID, err := currentOrganization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var org *Organization
+	err = s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		dbOrg, err := q.GetOrganizationByID(ctx, organizationID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrOrganizationNotFound
+			}
+			return err
+		}
+		org = toOrganizationModel(dbOrg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+func (s *OrganizationService) ListMembers(ctx context.Context) ([]*Member, error) {
+	ctx, span := tracing.Start(ctx, "organization.ListMembers")
+	defer span.End()
+
+	organizationID, err := currentOrganization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []database.ListOrganizationMembersRow
+	err = s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err = q.ListOrganizationMembers(ctx, organizationID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*Member, len(rows))
+	for i, row := range rows {
+		members[i] = &Member{
+			UserID:      row.UserID,
+			Email:       row.Email,
+			DisplayName: row.DisplayName,
+			RoleID:      row.RoleID,
+			JoinedAt:    row.CreatedAt,
+		}
+	}
+
+	return members, nil
+}
+
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, userID, roleID int64) error {
+	ctx, span := tracing.Start(ctx, "organization.UpdateMemberRole")
+	defer span.End()
+
+	organizationID, err := currentOrganization(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		if err := checkAssignable(ctx, q, organizationID, roleID); err != nil {
+			return err
+		}
+
+		member, err := q.GetOrganizationMember(ctx, database.GetOrganizationMemberParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrMemberNotFound
+			}
+			return err
+		}
+		if err := checkManageable(ctx, q, organizationID, member); err != nil {
+			return err
+		}
+		if err := checkNotLastOwner(ctx, q, organizationID, member, &roleID); err != nil {
+			return err
+		}
+
+		if _, err := q.UpdateOrganizationMemberRole(ctx, database.UpdateOrganizationMemberRoleParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+			RoleID:         roleID,
+		}); err != nil {
+			return err
+		}
+
+		return s.auditor.Record(ctx, q, audit.Event{
+			Action:     audit.ActionMemberRoleChanged,
+			TargetType: audit.TargetUser,
+			TargetID:   &userID,
+			Before:     map[string]any{"role_id": member.RoleID},
+			After:      map[string]any{"role_id": roleID},
+			Metadata:   map[string]any{"organization_id": organizationID},
+		})
+	})
+}
+
+func (s *OrganizationService) RemoveMember(ctx context.Context, userID int64) error {
+	ctx, span := tracing.Start(ctx, "organization.RemoveMember")
+	defer span.End()
+
+	organizationID, err := currentOrganization(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		member, err := q.GetOrganizationMember(ctx, database.GetOrganizationMemberParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrMemberNotFound
+			}
+			return err
+		}
+		if err := checkManageable(ctx, q, organizationID, member); err != nil {
+			return err
+		}
+		if err := checkNotLastOwner(ctx, q, organizationID, member, nil); err != nil {
+			return err
+		}
+
+		if _, err := q.RemoveOrganizationMember(ctx, database.RemoveOrganizationMemberParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+		}); err != nil {
+			return err
+		}
+
+		return s.auditor.Record(ctx, q, audit.Event{
+			Action:     audit.ActionMemberRemoved,
+			TargetType: audit.TargetUser,
+			TargetID:   &userID,
+			Metadata:   map[string]any{"organization_id": organizationID},
+		})
+	})
+}
+
+func (s *OrganizationService) IsMember(ctx context.Context, organizationID, userID int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "organization.IsMember")
+	defer span.End()
+
+	// Runs before the tenant middleware has scoped the request, so scope it here
+	err := s.txm.WithTx(tenant.WithOrganizationID(ctx, organizationID), func(ctx context.Context, q database.Querier) error {
+		_, err := q.GetOrganizationMember(ctx, database.GetOrganizationMemberParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *OrganizationService) HasOrganizationPermission(ctx context.Context, organizationID, userID int64, permission string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "organization.HasOrganizationPermission")
+	defer span.End()
+
+	var allowed bool
+	err := s.txm.WithTx(tenant.WithOrganizationID(ctx, organizationID), func(ctx context.Context, q database.Querier) error {
+		var err error
+		allowed, err = q.MemberHasPermission(ctx, database.MemberHasPermissionParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+			Permission:     permission,
+		})
+		return err
+	})
+	return allowed, err
+}
+
+// checkAssignable rejects roles that can't be given to members: global roles, any role
+// carrying system:superadmin, and roles with a permission the caller lacks in the
+// organization. Without an authenticated caller only the first two rules apply. q must
+// be scoped to the organization to see its members.
+func checkAssignable(ctx context.Context, q database.Querier, organizationID, roleID int64) error {
+	if _, err := q.GetRoleByID(ctx, roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+
+	var actor *int64
+	if userID, ok := reqctx.UserID(ctx); ok {
+		actor = &userID
+	}
+	assignable, err := q.CanAssignOrganizationRole(ctx, database.CanAssignOrganizationRoleParams{
+		RoleID:         roleID,
+		ActorID:        actor,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return err
+	}
+	if !assignable {
+		return ErrRoleNotAssignable
+	}
+	return nil
+}
+
+// checkManageable rejects changes to a member whose current role has a permission the
+// caller lacks in the organization, so members can't demote or remove those above them.
+// Without an authenticated caller the change is allowed.
+func checkManageable(ctx context.Context, q database.Querier, organizationID int64, member database.OrganizationMember) error {
+	actor, ok := reqctx.UserID(ctx)
+	if !ok {
+		return nil
+	}
+
+	holds, err := q.HoldsRolePermissions(ctx, database.HoldsRolePermissionsParams{
+		RoleID:         member.RoleID,
+		OrganizationID: organizationID,
+		ActorID:        actor,
+	})
+	if err != nil {
+		return err
+	}
+	if !holds {
+		return ErrMemberNotManageable
+	}
+	return nil
+}
+
+// checkNotLastOwner refuses to take the OwnerRole from the organization's last member
+// holding it, by giving them newRoleID or, when nil, removing them. The owners stay
+// locked until the transaction ends, so concurrent changes can't both pass.
+func checkNotLastOwner(ctx context.Context, q database.Querier, organizationID int64, member database.OrganizationMember, newRoleID *int64) error {
+	ownerRole, err := q.GetRoleByName(ctx, OwnerRole)
+	if err != nil {
+		return err
+	}
+	if member.RoleID != ownerRole.ID || (newRoleID != nil && *newRoleID == ownerRole.ID) {
+		return nil
+	}
+
+	owners, err := q.LockOrganizationMembersWithRole(ctx, database.LockOrganizationMembersWithRoleParams{
+		OrganizationID: organizationID,
+		RoleID:         ownerRole.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(owners) <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
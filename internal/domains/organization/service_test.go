@@ -0,0 +1,247 @@
+package organization_test
+
+import (
+	"context"
+	"fmt"
+	"go-web-template/internal/database"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/reqctx"
+	"go-web-template/internal/tenant"
+	"go-web-template/tests/integration"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OrganizationServiceTestSuite struct {
+	integration.ServiceIntegrationSuite
+}
+
+func TestOrganizationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationServiceTestSuite))
+}
+
+func (s *OrganizationServiceTestSuite) TestCreateOrganization_OwnerIsAdmin() {
+	svc := s.TC.Services.OrganizationService
+	owner := s.seedUser("owner@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	allowed, err := svc.HasOrganizationPermission(context.Background(), org.ID, owner, "users:write")
+	s.Require().NoError(err)
+	s.Assert().True(allowed)
+
+	_, err = svc.CreateOrganization(context.Background(), owner, "Acme Again", "acme")
+	s.Assert().ErrorIs(err, organization.ErrSlugTaken)
+}
+
+func (s *OrganizationServiceTestSuite) TestPermissionsArePerOrganization() {
+	svc := s.TC.Services.OrganizationService
+	alice := s.seedUser("alice@example.com")
+	bob := s.seedUser("bob@example.com")
+
+	orgA, err := svc.CreateOrganization(context.Background(), alice, "Org A", "org-a")
+	s.Require().NoError(err)
+	orgB, err := svc.CreateOrganization(context.Background(), bob, "Org B", "org-b")
+	s.Require().NoError(err)
+
+	// Alice joins org B as a regular user
+	s.joinOrganization(bob, orgB.ID, "alice@example.com", "user")
+	ctxB := tenant.WithOrganizationID(context.Background(), orgB.ID)
+
+	allowedA, err := svc.HasOrganizationPermission(context.Background(), orgA.ID, alice, "users:write")
+	s.Require().NoError(err)
+	s.Assert().True(allowedA)
+
+	allowedB, err := svc.HasOrganizationPermission(context.Background(), orgB.ID, alice, "users:write")
+	s.Require().NoError(err)
+	s.Assert().False(allowedB)
+
+	members, err := svc.ListMembers(ctxB)
+	s.Require().NoError(err)
+	s.Assert().Len(members, 2)
+}
+
+func (s *OrganizationServiceTestSuite) TestListMembers_RequiresTenant() {
+	_, err := s.TC.Services.OrganizationService.ListMembers(context.Background())
+	s.Assert().ErrorIs(err, organization.ErrNoOrganization)
+}
+
+func (s *OrganizationServiceTestSuite) TestUpdateMemberRole_IsAudited() {
+	svc := s.TC.Services.OrganizationService
+	owner := s.seedUser("owner@example.com")
+	member := s.seedUser("member@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	userRole, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+	adminRole, err := s.TC.Queries.GetRoleByName(context.Background(), organization.OwnerRole)
+	s.Require().NoError(err)
+
+	s.joinOrganization(owner, org.ID, "member@example.com", "user")
+	ctx := tenant.WithOrganizationID(context.Background(), org.ID)
+	s.Require().NoError(svc.UpdateMemberRole(ctx, member, adminRole.ID))
+
+	events, err := s.TC.Services.AuditService.ListEvents(context.Background(), audit.Filter{
+		Action:   audit.ActionMemberRoleChanged,
+		TargetID: &member,
+	}, 1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events.Data, 1)
+	s.Assert().JSONEq(
+		fmt.Sprintf(`{"role_id": {"before": %d, "after": %d}}`, userRole.ID, adminRole.ID),
+		string(events.Data[0].Changes),
+	)
+}
+
+func (s *OrganizationServiceTestSuite) TestUpdateMemberRole_RejectsSuperAdmin() {
+	svc := s.TC.Services.OrganizationService
+	owner := s.seedUser("owner@example.com")
+	member := s.seedUser("member@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+	s.joinOrganization(owner, org.ID, "member@example.com", "user")
+
+	superAdmin, err := s.TC.Queries.GetRoleByName(context.Background(), "super-admin")
+	s.Require().NoError(err)
+
+	ctx := tenant.WithOrganizationID(context.Background(), org.ID)
+	err = svc.UpdateMemberRole(ctx, member, superAdmin.ID)
+	s.Assert().ErrorIs(err, organization.ErrRoleNotAssignable)
+
+	allowed, err := svc.HasOrganizationPermission(context.Background(), org.ID, member, "users:write")
+	s.Require().NoError(err)
+	s.Assert().False(allowed)
+}
+
+func (s *OrganizationServiceTestSuite) TestLastOwnerCannotBeDemotedOrRemoved() {
+	svc := s.TC.Services.OrganizationService
+	owner := s.seedUser("owner@example.com")
+	member := s.seedUser("member@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+	s.joinOrganization(owner, org.ID, "member@example.com", "user")
+
+	userRole, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+	adminRole, err := s.TC.Queries.GetRoleByName(context.Background(), organization.OwnerRole)
+	s.Require().NoError(err)
+
+	ctx := reqctx.WithUserID(tenant.WithOrganizationID(context.Background(), org.ID), owner)
+	s.Assert().ErrorIs(svc.UpdateMemberRole(ctx, owner, userRole.ID), organization.ErrLastOwner)
+	s.Assert().ErrorIs(svc.RemoveMember(ctx, owner), organization.ErrLastOwner)
+
+	// With a second admin the first can step down
+	s.Require().NoError(svc.UpdateMemberRole(ctx, member, adminRole.ID))
+	s.Assert().NoError(svc.UpdateMemberRole(ctx, owner, userRole.ID))
+}
+
+func (s *OrganizationServiceTestSuite) TestMembersWithMorePermissionsCannotBeChanged() {
+	svc := s.TC.Services.OrganizationService
+	owner := s.seedUser("owner@example.com")
+	admin := s.seedUser("admin@example.com")
+	moderator := s.seedUser("moderator@example.com")
+	member := s.seedUser("member@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	// A role that may manage members but holds fewer permissions than an admin
+	moderatorRole, err := s.TC.Queries.CreateRole(context.Background(), database.CreateRoleParams{
+		Name:               "moderator",
+		OrganizationScoped: true,
+	})
+	s.Require().NoError(err)
+	for _, name := range []string{"users:read", "users:write", "users:delete"} {
+		permission, err := s.TC.Queries.GetPermissionByName(context.Background(), name)
+		s.Require().NoError(err)
+		s.Require().NoError(s.TC.Queries.AssignPermissionToRole(context.Background(), database.AssignPermissionToRoleParams{
+			RoleID:       moderatorRole.ID,
+			PermissionID: permission.ID,
+		}))
+	}
+
+	s.joinOrganization(owner, org.ID, "admin@example.com", organization.OwnerRole)
+	s.joinOrganization(owner, org.ID, "moderator@example.com", "moderator")
+	s.joinOrganization(owner, org.ID, "member@example.com", "user")
+
+	ctx := reqctx.WithUserID(tenant.WithOrganizationID(context.Background(), org.ID), moderator)
+	s.Assert().ErrorIs(svc.UpdateMemberRole(ctx, admin, moderatorRole.ID), organization.ErrMemberNotManageable)
+	s.Assert().ErrorIs(svc.RemoveMember(ctx, admin), organization.ErrMemberNotManageable)
+
+	// Members the moderator outranks are theirs to manage
+	s.Assert().NoError(svc.RemoveMember(ctx, member))
+}
+
+func (s *OrganizationServiceTestSuite) TestInvitation_JoinsExistingUser() {
+	svc := s.TC.Services.OrganizationService
+	owner := s.seedUser("owner@example.com")
+	member := s.seedUser("member@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	isMember, err := svc.IsMember(context.Background(), org.ID, member)
+	s.Require().NoError(err)
+	s.Assert().False(isMember)
+
+	s.joinOrganization(owner, org.ID, "member@example.com", "user")
+
+	isMember, err = svc.IsMember(context.Background(), org.ID, member)
+	s.Require().NoError(err)
+	s.Assert().True(isMember)
+
+	// A second invitation to a member is refused up front
+	userRole, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+	_, _, err = s.TC.Services.InvitationService.CreateOrganizationInvitation(context.Background(), owner, org.ID, "member@example.com", userRole.ID)
+	s.Assert().ErrorIs(err, invitation.ErrAlreadyMember)
+}
+
+func (s *OrganizationServiceTestSuite) TestInvitation_RejectsGlobalRoles() {
+	owner := s.seedUser("owner@example.com")
+	org, err := s.TC.Services.OrganizationService.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	s.Require().NoError(err)
+
+	superAdmin, err := s.TC.Queries.GetRoleByName(context.Background(), "super-admin")
+	s.Require().NoError(err)
+
+	_, _, err = s.TC.Services.InvitationService.CreateOrganizationInvitation(context.Background(), owner, org.ID, "new@example.com", superAdmin.ID)
+	s.Assert().ErrorIs(err, invitation.ErrRoleNotAssignable)
+}
+
+// joinOrganization invites email to organizationID with role and accepts as the
+// invitee's existing account.
+func (s *OrganizationServiceTestSuite) joinOrganization(inviter, organizationID int64, email, role string) {
+	dbRole, err := s.TC.Queries.GetRoleByName(context.Background(), role)
+	s.Require().NoError(err)
+	invitee, err := s.TC.Queries.GetUserByEmail(context.Background(), email)
+	s.Require().NoError(err)
+
+	invitations := s.TC.Services.InvitationService
+	_, token, err := invitations.CreateOrganizationInvitation(context.Background(), inviter, organizationID, email, dbRole.ID)
+	s.Require().NoError(err)
+	_, err = invitations.JoinOrganization(context.Background(), token, invitee.ID)
+	s.Require().NoError(err)
+}
+
+func (s *OrganizationServiceTestSuite) seedUser(email string) int64 {
+	role, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+
+	u, err := s.TC.Queries.CreateUser(context.Background(), database.CreateUserParams{
+		Email:       email,
+		Password:    "hashed",
+		DisplayName: email,
+		RoleID:      role.ID,
+	})
+	s.Require().NoError(err)
+	return u.ID
+}
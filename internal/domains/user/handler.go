@@ -1,6 +1,7 @@
 package user
 
 import (
+	"go-web-template/internal/openapi"
 	"go-web-template/internal/utils"
 	"net/http"
 	"strconv"
@@ -24,13 +25,26 @@ func (h *UserHandler) Routes() chi.Router {
 	return r
 }
 
+// Operations documents Routes for the OpenAPI spec.
+func (h *UserHandler) Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/", ID: "listUsers", Summary: "List users", Response: PaginatedUsers{}, Auth: true,
+			Query: []openapi.Param{
+				{Name: "page", Type: "integer", Description: "1-based page number"},
+				{Name: "page_size", Type: "integer", Description: "Users per page, at most 100"},
+			},
+		},
+	}
+}
+
 func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
 
 	result, err := h.service.ListUsers(r.Context(), page, pageSize)
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "failed to fetch users")
+		utils.RespondProblem(w, r, err)
 		return
 	}
 
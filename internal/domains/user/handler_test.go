@@ -2,6 +2,7 @@ package user_test
 
 import (
 	"encoding/json"
+	"go-web-template/internal/apperr"
 	"go-web-template/internal/domains/user"
 	"go-web-template/internal/utils"
 	"net/http"
@@ -123,10 +124,14 @@ func (suite *UserHandlerTestSuite) TestListUsers_ServiceError() {
 
 	suite.Equal(http.StatusInternalServerError, w.Code)
 
-	var response utils.ErrorResponse
+	suite.Equal("application/problem+json", w.Header().Get("Content-Type"))
+
+	var response utils.ProblemDetails
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
-	suite.Equal("failed to fetch users", response.Message)
+	suite.Equal(apperr.CodeInternal, response.Code)
+	suite.Equal(http.StatusInternalServerError, response.Status)
+	suite.NotContains(w.Body.String(), assert.AnError.Error())
 }
 
 // Test default pagination values
@@ -24,13 +24,12 @@ type PaginatedUsers struct {
 }
 
 type Role struct {
-	ID          int64        `json:"id"`
-	Name        string       `json:"name"`
-	IsDefault   bool         `json:"is_default"`
-	Description *string      `json:"description,omitempty"`
-	Permissions []Permission `json:"permissions,omitempty"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	IsDefault   bool      `json:"is_default"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type Permission struct {
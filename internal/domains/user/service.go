@@ -3,45 +3,43 @@ package user
 import (
 	"context"
 	"go-web-template/internal/database"
+	"go-web-template/internal/tracing"
 )
 
 type UserServiceInterface interface {
 	ListUsers(ctx context.Context, page, pageSize int) (*PaginatedUsers, error)
+	HasPermission(ctx context.Context, userID int64, permission string) (bool, error)
 }
 
 var _ UserServiceInterface = (*UserService)(nil)
 
 type UserService struct {
-	queries *database.Queries
+	queries database.Querier
 }
 
-func NewUserService(queries *database.Queries) *UserService {
+func NewUserService(queries database.Querier) *UserService {
 	return &UserService{
 		queries: queries,
 	}
 }
 
 func toUserModel(dbUser database.User) *User {
-	user := &User{
-		ID:          dbUser.ID,
-		Email:       dbUser.Email,
-		DisplayName: dbUser.DisplayName,
-		RoleID:      dbUser.RoleID,
-		CreatedAt:   dbUser.CreatedAt,
-		UpdatedAt:   dbUser.UpdatedAt,
+	return &User{
+		ID:             dbUser.ID,
+		Email:          dbUser.Email,
+		DisplayName:    dbUser.DisplayName,
+		RoleID:         dbUser.RoleID,
+		EmailConfirmed: dbUser.EmailConfirmed,
+		CreatedAt:      dbUser.CreatedAt,
+		UpdatedAt:      dbUser.UpdatedAt,
+		DeletedAt:      dbUser.DeletedAt,
 	}
-
-	if dbUser.EmailConfirmed.Valid {
-		user.EmailConfirmed = &dbUser.EmailConfirmed.Time
-	}
-	if dbUser.DeletedAt.Valid {
-		user.DeletedAt = &dbUser.DeletedAt.Time
-	}
-
-	return user
 }
 
 func (s *UserService) ListUsers(ctx context.Context, page, pageSize int) (*PaginatedUsers, error) {
+	ctx, span := tracing.Start(ctx, "user.ListUsers")
+	defer span.End()
+
 	if page < 1 {
 		page = 1
 	}
@@ -80,3 +78,13 @@ func (s *UserService) ListUsers(ctx context.Context, page, pageSize int) (*Pagin
 		TotalPages: totalPages,
 	}, nil
 }
+
+func (s *UserService) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "user.HasPermission")
+	defer span.End()
+
+	return s.queries.UserHasPermission(ctx, database.UserHasPermissionParams{
+		UserID:     userID,
+		Permission: permission,
+	})
+}
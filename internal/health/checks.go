@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"net"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+)
+
+// DBPing checks that a connection to the database can be made and used.
+func DBPing(pool *pgxpool.Pool) Check {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// Migrations checks that the database schema is at the newest migration in
+// migrations and goMigrations, so an instance never serves traffic against a schema it
+// doesn't expect. goose needs a *sql.DB, e.g. stdlib.OpenDBFromPool(pool).
+func Migrations(db *sql.DB, migrations fs.FS, goMigrations ...*goose.Migration) (Check, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations,
+		goose.WithGoMigrations(goMigrations...),
+		goose.WithDisableGlobalRegistry(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		current, target, err := provider.GetVersions(ctx)
+		if err != nil {
+			return err
+		}
+		if current < target {
+			return fmt.Errorf("database is at migration %d, expected %d", current, target)
+		}
+		return nil
+	}, nil
+}
+
+// Dial checks that a TCP connection to address can be opened, e.g. to an SMTP relay or
+// another service without a health endpoint of its own.
+func Dial(address string) Check {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,221 @@
+// Package health serves the liveness and readiness probes. Readiness runs the checks
+// registered on a Registry, e.g. the database ping, and reports each one with its
+// latency so a failing dependency is visible from the probe response alone.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-web-template/pkg/logging"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	StatusOK           = "ok"
+	StatusUnavailable  = "unavailable"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Check reports whether a dependency is usable. The context carries the per-check
+// timeout.
+type Check func(ctx context.Context) error
+
+// CheckResult is one check's outcome. Error can name hosts or internal state, so only
+// the admin listener's probe includes it.
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status    string        `json:"status"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Registry runs the readiness checks. Results are cached for cacheTTL, so frequent
+// probes from several load balancers don't each hit every dependency.
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	mu       sync.Mutex
+	checks   []namedCheck
+	cached   *Report
+	cachedAt time.Time
+
+	shuttingDown atomic.Bool
+	now          func() time.Time
+}
+
+func NewRegistry(timeout, cacheTTL time.Duration) *Registry {
+	return &Registry{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+	}
+}
+
+// Register adds a readiness check. Names should be unique; they identify the check in
+// the report.
+func (r *Registry) Register(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, namedCheck{name: name, check: check})
+	r.cached = nil
+}
+
+// ShutDown makes readiness fail from now on, so load balancers stop routing new
+// requests while the server drains the ones in flight.
+func (r *Registry) ShutDown() {
+	r.shuttingDown.Store(true)
+}
+
+// Check runs every check concurrently, each under the registry timeout, unless a report
+// younger than the cache TTL is available.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cached != nil && r.now().Sub(r.cachedAt) < r.cacheTTL {
+		return *r.cached
+	}
+
+	report := Report{
+		Status:    StatusOK,
+		CheckedAt: r.now().UTC(),
+		Checks:    make([]CheckResult, len(r.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = r.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+
+	r.cached = &report
+	r.cachedAt = r.now()
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, c namedCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := safeCheck(ctx, c.check)
+	result := CheckResult{
+		Name:      c.name,
+		Status:    StatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+		logging.FromContext(ctx).Warn("readiness check failed", zap.String("check", c.name), zap.Error(err))
+	}
+	return result
+}
+
+// safeCheck turns a panicking check into a failed one rather than crashing the probe.
+func safeCheck(ctx context.Context, check Check) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("check panicked: %v", p)
+		}
+	}()
+	return check(ctx)
+}
+
+// LivenessHandler reports that the process is serving requests. It deliberately checks
+// no dependencies: a database outage should not get the process restarted.
+func (r *Registry) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, req, http.StatusOK, Report{
+			Status:    StatusOK,
+			CheckedAt: r.now().UTC(),
+			Checks:    []CheckResult{},
+		})
+	}
+}
+
+// ReadinessHandler responds 200 when every check passes and 503 otherwise, including
+// once ShutDown has been called. Failed checks are reported by name and status only;
+// their errors are logged.
+func (r *Registry) ReadinessHandler() http.HandlerFunc {
+	return r.readiness(false)
+}
+
+// DetailedReadinessHandler is ReadinessHandler with each failed check's error, for the
+// admin listener.
+func (r *Registry) DetailedReadinessHandler() http.HandlerFunc {
+	return r.readiness(true)
+}
+
+func (r *Registry) readiness(detailed bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if r.shuttingDown.Load() {
+			writeJSON(w, req, http.StatusServiceUnavailable, Report{
+				Status:    StatusShuttingDown,
+				CheckedAt: r.now().UTC(),
+				Checks:    []CheckResult{},
+			})
+			return
+		}
+
+		report := r.Check(req.Context())
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		if !detailed {
+			report = report.redacted()
+		}
+		writeJSON(w, req, status, report)
+	}
+}
+
+// redacted copies the report without check errors; the cached one keeps them.
+func (rep Report) redacted() Report {
+	checks := make([]CheckResult, len(rep.Checks))
+	for i, c := range rep.Checks {
+		c.Error = ""
+		checks[i] = c
+	}
+	rep.Checks = checks
+	return rep
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		// The prober went away; there is nobody left to tell
+		logging.FromContext(r.Context()).Debug("failed to write health response", zap.Error(err))
+	}
+}
@@ -0,0 +1,132 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-web-template/internal/health"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (suite *HealthTestSuite) probe(handler http.HandlerFunc) (int, health.Report) {
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report health.Report
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+	return w.Code, report
+}
+
+func (suite *HealthTestSuite) TestReadyWhenAllChecksPass() {
+	checks := health.NewRegistry(time.Second, 0)
+	checks.Register("database", func(context.Context) error { return nil })
+
+	status, report := suite.probe(checks.ReadinessHandler())
+
+	suite.Equal(http.StatusOK, status)
+	suite.Equal(health.StatusOK, report.Status)
+	suite.Require().Len(report.Checks, 1)
+	suite.Equal("database", report.Checks[0].Name)
+	suite.Equal(health.StatusOK, report.Checks[0].Status)
+	suite.GreaterOrEqual(report.Checks[0].LatencyMS, 0.0)
+}
+
+func (suite *HealthTestSuite) TestNotReadyWhenACheckFails() {
+	checks := health.NewRegistry(50*time.Millisecond, 0)
+	checks.Register("database", func(context.Context) error { return nil })
+	checks.Register("mail", func(context.Context) error { return errors.New("connection refused") })
+	checks.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	checks.Register("broken", func(context.Context) error { panic("nil map") })
+
+	status, report := suite.probe(checks.DetailedReadinessHandler())
+
+	suite.Equal(http.StatusServiceUnavailable, status)
+	suite.Equal(health.StatusUnavailable, report.Status)
+	suite.Require().Len(report.Checks, 4)
+	suite.Equal(health.StatusOK, report.Checks[0].Status)
+	suite.Equal("connection refused", report.Checks[1].Error)
+	suite.Equal(context.DeadlineExceeded.Error(), report.Checks[2].Error)
+	suite.Equal("check panicked: nil map", report.Checks[3].Error)
+}
+
+func (suite *HealthTestSuite) TestReadinessHidesCheckErrors() {
+	checks := health.NewRegistry(time.Second, time.Minute)
+	checks.Register("database", func(context.Context) error {
+		return errors.New("dial tcp 10.0.3.7:5432: connection refused")
+	})
+
+	status, report := suite.probe(checks.ReadinessHandler())
+
+	suite.Equal(http.StatusServiceUnavailable, status)
+	suite.Require().Len(report.Checks, 1)
+	suite.Equal("database", report.Checks[0].Name)
+	suite.Equal(health.StatusUnavailable, report.Checks[0].Status)
+	suite.Empty(report.Checks[0].Error)
+
+	// The cached report still has the error for the admin listener
+	_, report = suite.probe(checks.DetailedReadinessHandler())
+	suite.Contains(report.Checks[0].Error, "connection refused")
+}
+
+func (suite *HealthTestSuite) TestCachesResults() {
+	var calls atomic.Int32
+	check := func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}
+
+	cached := health.NewRegistry(time.Second, time.Minute)
+	cached.Register("database", check)
+	cached.Check(context.Background())
+	cached.Check(context.Background())
+	suite.Equal(int32(1), calls.Load())
+
+	uncached := health.NewRegistry(time.Second, 0)
+	uncached.Register("database", check)
+	uncached.Check(context.Background())
+	uncached.Check(context.Background())
+	suite.Equal(int32(3), calls.Load())
+}
+
+func (suite *HealthTestSuite) TestShutDownFailsReadinessOnly() {
+	checks := health.NewRegistry(time.Second, time.Minute)
+	checks.Register("database", func(context.Context) error { return nil })
+	checks.ShutDown()
+
+	status, report := suite.probe(checks.ReadinessHandler())
+	suite.Equal(http.StatusServiceUnavailable, status)
+	suite.Equal(health.StatusShuttingDown, report.Status)
+
+	status, report = suite.probe(checks.LivenessHandler())
+	suite.Equal(http.StatusOK, status)
+	suite.Equal(health.StatusOK, report.Status)
+}
+
+func (suite *HealthTestSuite) TestDial() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	address := listener.Addr().String()
+
+	suite.NoError(health.Dial(address)(context.Background()))
+
+	suite.Require().NoError(listener.Close())
+	suite.Error(health.Dial(address)(context.Background()))
+}
@@ -0,0 +1,92 @@
+// Package jsonfield describes Go types the way encoding/json marshals them, for the
+// generators that document the API's JSON: the OpenAPI spec and the TypeScript types.
+package jsonfield
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	// TimeType marshals as an RFC 3339 string.
+	TimeType = reflect.TypeOf(time.Time{})
+	// RawMessageType is arbitrary JSON.
+	RawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Field is a property of a struct's JSON object.
+type Field struct {
+	Name string
+	// Type is the field's type, without the pointer of an omitempty pointer field: such
+	// a property is omitted rather than null when unset.
+	Type      reflect.Type
+	OmitEmpty bool
+}
+
+// Fields lists the properties of struct type t in field order. Embedded structs without
+// a tag name are flattened, as encoding/json does.
+func Fields(t reflect.Type) []Field {
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitempty, ok := parseTag(field)
+		if !ok {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, Fields(ft)...)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		ft := field.Type
+		if omitempty && ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		fields = append(fields, Field{Name: name, Type: ft, OmitEmpty: omitempty})
+	}
+	return fields
+}
+
+// Elem is the element type of slice or array type t. Elements of []*T are never null in
+// practice, so the pointer is dropped.
+func Elem(t reflect.Type) reflect.Type {
+	elem := t.Elem()
+	if elem.Kind() == reflect.Pointer {
+		return elem.Elem()
+	}
+	return elem
+}
+
+// parseTag reports the JSON name and omitempty flag of field, and false when
+// encoding/json skips it.
+func parseTag(field reflect.StructField) (name string, omitempty, ok bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty, true
+}
@@ -0,0 +1,55 @@
+package jsonfield_test
+
+import (
+	"go-web-template/internal/jsonfield"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type base struct {
+	ID int64 `json:"id"`
+}
+
+type post struct {
+	base
+	Title    string  `json:"title"`
+	Summary  *string `json:"summary,omitempty"`
+	Subtitle *string `json:"subtitle"`
+	Views    int     `json:"views,omitzero"`
+	Tags     []*post `json:"tags"`
+	Untagged bool    `json:",omitempty"`
+	Password string  `json:"-"`
+	internal string
+	Nested   struct{} `json:"nested"`
+}
+
+type JSONFieldTestSuite struct {
+	suite.Suite
+}
+
+func TestJSONFieldTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONFieldTestSuite))
+}
+
+func (suite *JSONFieldTestSuite) TestFields() {
+	stringType := reflect.TypeOf("")
+	fields := jsonfield.Fields(reflect.TypeOf(post{}))
+
+	suite.Equal([]jsonfield.Field{
+		{Name: "id", Type: reflect.TypeOf(int64(0))},
+		{Name: "title", Type: stringType},
+		{Name: "summary", Type: stringType, OmitEmpty: true},
+		{Name: "subtitle", Type: reflect.PointerTo(stringType)},
+		{Name: "views", Type: reflect.TypeOf(0), OmitEmpty: true},
+		{Name: "tags", Type: reflect.TypeOf([]*post{})},
+		{Name: "Untagged", Type: reflect.TypeOf(false), OmitEmpty: true},
+		{Name: "nested", Type: reflect.TypeOf(struct{}{})},
+	}, fields)
+}
+
+func (suite *JSONFieldTestSuite) TestElem() {
+	suite.Equal(reflect.TypeOf(post{}), jsonfield.Elem(reflect.TypeOf([]*post{})))
+	suite.Equal(reflect.TypeOf(""), jsonfield.Elem(reflect.TypeOf([]string{})))
+}
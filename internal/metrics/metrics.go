@@ -0,0 +1,186 @@
+// Package metrics exposes the service's Prometheus metrics. All collectors are
+// registered on a Metrics-owned registry rather than the global default, so tests can
+// create as many as they need.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute labels requests no route matched, so 404 scans don't create a series
+// per path.
+const unmatchedRoute = "unmatched"
+
+type routeKey struct{}
+
+// routeMatch is set by NotFound when the router found no route for the request.
+type routeMatch struct {
+	unmatched bool
+}
+
+type Metrics struct {
+	registry         *prometheus.Registry
+	requestDuration  *prometheus.HistogramVec
+	requestsInFlight prometheus.Gauge
+	loginAttempts    *prometheus.CounterVec
+	queryDuration    *prometheus.HistogramVec
+	queries          *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests by chi route pattern.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		}),
+		loginAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_login_attempts_total",
+			Help: "Login attempts by result (success or failure).",
+		}, []string{"result"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries by sqlc query name.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"query"}),
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_queries_total",
+			Help: "Database queries by sqlc query name and result (ok or error).",
+		}, []string{"query", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		buildInfo(),
+		m.requestDuration,
+		m.requestsInFlight,
+		m.loginAttempts,
+		m.queryDuration,
+		m.queries,
+	)
+
+	return m
+}
+
+// RegisterDB exports the connection pool statistics of pool as pgxpool_* gauges and
+// counters labelled with dbName.
+func (m *Metrics) RegisterDB(pool *pgxpool.Pool, dbName string) {
+	m.registry.MustRegister(newPoolCollector(pool, dbName))
+}
+
+// ObserveLogin counts a login attempt. It is a no-op on a nil *Metrics, so handlers can
+// be built without metrics in tests.
+func (m *Metrics) ObserveLogin(success bool) {
+	if m == nil {
+		return
+	}
+
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	m.loginAttempts.WithLabelValues(result).Inc()
+}
+
+// ObserveQuery records a query named by tracing.QueryName. Like ObserveLogin it is a
+// no-op on a nil *Metrics.
+func (m *Metrics) ObserveQuery(name string, duration time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+
+	result := "ok"
+	if failed {
+		result = "error"
+	}
+	m.queries.WithLabelValues(name, result).Inc()
+	m.queryDuration.WithLabelValues(name).Observe(duration.Seconds())
+}
+
+// Middleware records request durations. The route label is read after the handler has
+// run, once chi has resolved the full pattern, e.g. /api/organizations/{orgID}/members.
+// Requests served by the router's NotFound handler, wrapped with NotFound, are labelled
+// unmatched; a 404 from a matched route keeps its pattern.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.requestsInFlight.Inc()
+		defer m.requestsInFlight.Dec()
+
+		match := &routeMatch{}
+		r = r.WithContext(context.WithValue(r.Context(), routeKey{}, match))
+
+		start := time.Now()
+		ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" && !match.unmatched {
+			route = rctx.RoutePattern()
+		}
+
+		m.requestDuration.
+			WithLabelValues(r.Method, route, strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// NotFound wraps the router's NotFound handler. A request that falls through a mounted
+// router still carries the mount's pattern, e.g. /api/*, which would otherwise label it.
+func (m *Metrics) NotFound(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if match, ok := r.Context().Value(routeKey{}).(*routeMatch); ok {
+			match.unmatched = true
+		}
+		next(w, r)
+	}
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// buildInfo is a constant 1 gauge labelled with the module version and VCS revision
+// stamped into the binary.
+func buildInfo() prometheus.Collector {
+	version, revision, goVersion := "unknown", "unknown", "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		goVersion = info.GoVersion
+		if info.Main.Version != "" {
+			version = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				revision = setting.Value
+			}
+		}
+	}
+
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "build_info",
+		Help:        "Build information of the running binary.",
+		ConstLabels: prometheus.Labels{"version": version, "revision": revision, "goversion": goVersion},
+	}, func() float64 { return 1 })
+}
@@ -0,0 +1,104 @@
+package metrics_test
+
+import (
+	"context"
+	"go-web-template/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	metrics *metrics.Metrics
+	router  *chi.Mux
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (suite *MetricsTestSuite) SetupTest() {
+	suite.metrics = metrics.New()
+
+	suite.router = chi.NewRouter()
+	suite.router.Use(suite.metrics.Middleware)
+	suite.router.NotFound(suite.metrics.NotFound(http.NotFound))
+	suite.router.Route("/items", func(r chi.Router) {
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			if chi.URLParam(r, "id") == "missing" {
+				// A matched route reporting a missing resource
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusTeapot)
+		})
+	})
+}
+
+func (suite *MetricsTestSuite) scrape() string {
+	w := httptest.NewRecorder()
+	suite.metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	suite.Require().Equal(http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+func (suite *MetricsTestSuite) TestRequestsAreLabelledByRoutePattern() {
+	for _, path := range []string{"/items/1", "/items/2", "/items/missing", "/nope/3", "/items/1/nope"} {
+		suite.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := suite.scrape()
+	suite.Contains(body, `http_request_duration_seconds_count{method="GET",route="/items/{id}",status="418"} 2`)
+	suite.Contains(body, `http_request_duration_seconds_count{method="GET",route="/items/{id}",status="404"} 1`)
+	// Including the path that fell through the /items router
+	suite.Contains(body, `http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 2`)
+	suite.Contains(body, "http_requests_in_flight 0")
+}
+
+func (suite *MetricsTestSuite) TestLoginsAndBuildInfo() {
+	suite.metrics.ObserveLogin(true)
+	suite.metrics.ObserveLogin(false)
+	suite.metrics.ObserveLogin(false)
+
+	body := suite.scrape()
+	suite.Contains(body, `auth_login_attempts_total{result="success"} 1`)
+	suite.Contains(body, `auth_login_attempts_total{result="failure"} 2`)
+	suite.Contains(body, "build_info{")
+	suite.Contains(body, "go_goroutines")
+}
+
+func (suite *MetricsTestSuite) TestNilMetricsIgnoresLogins() {
+	var m *metrics.Metrics
+	suite.NotPanics(func() { m.ObserveLogin(true) })
+	suite.NotPanics(func() { m.ObserveQuery("GetUserByID", time.Millisecond, false) })
+}
+
+func (suite *MetricsTestSuite) TestQueries() {
+	suite.metrics.ObserveQuery("GetUserByID", 3*time.Millisecond, false)
+	suite.metrics.ObserveQuery("GetUserByID", 30*time.Millisecond, true)
+
+	body := suite.scrape()
+	suite.Contains(body, `db_queries_total{query="GetUserByID",result="ok"} 1`)
+	suite.Contains(body, `db_queries_total{query="GetUserByID",result="error"} 1`)
+	suite.Contains(body, `db_query_duration_seconds_bucket{query="GetUserByID",le="0.005"} 1`)
+	suite.Contains(body, `db_query_duration_seconds_count{query="GetUserByID"} 2`)
+}
+
+func (suite *MetricsTestSuite) TestRegisterDB() {
+	// The pool connects lazily, so stats are read without a database
+	pool, err := pgxpool.New(context.Background(), "host=127.0.0.1 dbname=app pool_max_conns=7")
+	suite.Require().NoError(err)
+	defer pool.Close()
+
+	suite.metrics.RegisterDB(pool, "app")
+	body := suite.scrape()
+	suite.Contains(body, `pgxpool_max_connections{db_name="app"} 7`)
+	suite.Contains(body, `pgxpool_total_connections{db_name="app"} 0`)
+	suite.Contains(body, `pgxpool_acquires_total{db_name="app"} 0`)
+}
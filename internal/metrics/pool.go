@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector exports the statistics of a pgx connection pool, read on every scrape.
+type poolCollector struct {
+	pool    *pgxpool.Pool
+	metrics []poolMetric
+}
+
+type poolMetric struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	value     func(*pgxpool.Stat) float64
+}
+
+func newPoolCollector(pool *pgxpool.Pool, dbName string) *poolCollector {
+	labels := prometheus.Labels{"db_name": dbName}
+	metric := func(name, help string, valueType prometheus.ValueType, value func(*pgxpool.Stat) float64) poolMetric {
+		return poolMetric{
+			desc:      prometheus.NewDesc("pgxpool_"+name, help, nil, labels),
+			valueType: valueType,
+			value:     value,
+		}
+	}
+
+	return &poolCollector{
+		pool: pool,
+		metrics: []poolMetric{
+			metric("max_connections", "Maximum size of the pool.", prometheus.GaugeValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) }),
+			metric("total_connections", "Open connections, including ones being established.", prometheus.GaugeValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) }),
+			metric("acquired_connections", "Connections currently in use.", prometheus.GaugeValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) }),
+			metric("idle_connections", "Idle connections.", prometheus.GaugeValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) }),
+			metric("acquires_total", "Connections acquired from the pool.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) }),
+			metric("empty_acquires_total", "Acquires that had to wait for a connection.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) }),
+			metric("empty_acquire_wait_seconds_total", "Time spent waiting for a connection.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return s.EmptyAcquireWaitTime().Seconds() }),
+			metric("canceled_acquires_total", "Acquires canceled by their context.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) }),
+			metric("new_connections_total", "Connections opened.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.NewConnsCount()) }),
+			metric("max_lifetime_closed_total", "Connections closed for exceeding DB_CONN_MAX_LIFETIME.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.MaxLifetimeDestroyCount()) }),
+			metric("max_idle_closed_total", "Connections closed for exceeding DB_CONN_MAX_IDLE_TIME.", prometheus.CounterValue,
+				func(s *pgxpool.Stat) float64 { return float64(s.MaxIdleDestroyCount()) }),
+		},
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.metrics {
+		ch <- m.desc
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	for _, m := range c.metrics {
+		ch <- prometheus.MustNewConstMetric(m.desc, m.valueType, m.value(stat))
+	}
+}
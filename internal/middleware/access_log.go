@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"go-web-template/internal/reqctx"
+	"go-web-template/pkg/logging"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type ctxKey int
+
+const accessLogKey ctxKey = iota
+
+// accessLogEntry collects what inner middleware learns about a request, such as the
+// authenticated user, for the access log line written once the response is done.
+type accessLogEntry struct {
+	userID int64
+}
+
+// AccessLog writes one structured line per request and stores a request-scoped logger,
+// tagged with the request ID and, when the request is traced, the trace and span IDs, in
+// the context for logging.FromContext. It should run after RequestID, RequestMetadata
+// and tracing.Middleware. Requests to skipPaths are served without a log
+// line, e.g. health checks.
+func AccessLog(logger *zap.Logger, skipPaths []string) func(http.Handler) http.Handler {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := chimw.GetReqID(r.Context())
+			reqLogger := logger.With(zap.String("request_id", requestID))
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				reqLogger = reqLogger.With(
+					zap.String("trace_id", sc.TraceID().String()),
+					zap.String("span_id", sc.SpanID().String()),
+				)
+			}
+
+			entry := &accessLogEntry{}
+			ctx := context.WithValue(r.Context(), accessLogKey, entry)
+			ctx = logging.WithContext(ctx, reqLogger)
+
+			if skip[r.URL.Path] {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					// Nothing was written, net/http sends 200
+					status = http.StatusOK
+				}
+
+				fields := []zap.Field{
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("pattern", routePattern(r)),
+					zap.Int("status", status),
+					zap.Int("bytes", ww.BytesWritten()),
+					zap.Duration("latency", time.Since(start)),
+					zap.String("ip", reqctx.ClientIP(ctx)),
+				}
+				if entry.userID != 0 {
+					fields = append(fields, zap.Int64("user_id", entry.userID))
+				}
+
+				reqLogger.Check(accessLogLevel(status), "request").Write(fields...)
+			}()
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		})
+	}
+}
+
+// routePattern is the matched chi route, e.g. /api/organizations/{orgID}/members, so
+// requests can be grouped without their IDs.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return ""
+}
+
+func accessLogLevel(status int) zapcore.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return zapcore.ErrorLevel
+	case status >= http.StatusBadRequest:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// withUserID records the authenticated user in ctx, in the access log entry and on the
+// request-scoped logger.
+func withUserID(ctx context.Context, userID int64) context.Context {
+	if entry, ok := ctx.Value(accessLogKey).(*accessLogEntry); ok {
+		entry.userID = userID
+	}
+
+	ctx = logging.WithContext(ctx, logging.FromContext(ctx).With(zap.Int64("user_id", userID)))
+	return reqctx.WithUserID(ctx, userID)
+}
@@ -0,0 +1,124 @@
+package middleware_test
+
+import (
+	"go-web-template/internal/config"
+	"go-web-template/internal/middleware"
+	"go-web-template/pkg/logging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type AccessLogTestSuite struct {
+	suite.Suite
+	logs   *observer.ObservedLogs
+	auth   *middleware.AuthMiddleware
+	router *chi.Mux
+}
+
+func TestAccessLogTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessLogTestSuite))
+}
+
+func (suite *AccessLogTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	suite.logs = logs
+
+	cfg := &config.Config{}
+	cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+	suite.auth = middleware.NewAuthMiddleware(cfg, zap.NewNop(), time.Minute, time.Hour, time.Hour)
+
+	suite.router = chi.NewRouter()
+	suite.router.Use(chimw.RequestID)
+	suite.router.Use(middleware.RequestMetadata)
+	suite.router.Use(middleware.AccessLog(zap.New(core), []string{"/health"}))
+
+	suite.router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	suite.router.With(suite.auth.WebClientAuthentication).Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("loading item")
+		_, _ = w.Write([]byte("hello"))
+	})
+}
+
+func (suite *AccessLogTestSuite) serve(req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *AccessLogTestSuite) TestLogsRequest() {
+	access, refresh, err := suite.auth.GenerateLoginTokens(42, false)
+	suite.Require().NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7", nil)
+	req.AddCookie(&http.Cookie{Name: "access", Value: access})
+	req.AddCookie(&http.Cookie{Name: "refresh", Value: refresh})
+	suite.Require().Equal(http.StatusOK, suite.serve(req).Code)
+
+	entries := suite.logs.All()
+	suite.Require().Len(entries, 2)
+
+	// The handler's logger is scoped to the request and user
+	handlerLog := entries[0].ContextMap()
+	suite.Assert().Equal("loading item", entries[0].Message)
+	suite.Assert().NotEmpty(handlerLog["request_id"])
+	suite.Assert().Equal(int64(42), handlerLog["user_id"])
+
+	entry := entries[1]
+	fields := entry.ContextMap()
+	suite.Assert().Equal(zapcore.InfoLevel, entry.Level)
+	suite.Assert().Equal("GET", fields["method"])
+	suite.Assert().Equal("/items/7", fields["path"])
+	suite.Assert().Equal("/items/{id}", fields["pattern"])
+	suite.Assert().Equal(int64(200), fields["status"])
+	suite.Assert().Equal(int64(5), fields["bytes"])
+	suite.Assert().Equal(int64(42), fields["user_id"])
+	suite.Assert().Equal("192.0.2.1", fields["ip"])
+	suite.Assert().Equal(handlerLog["request_id"], fields["request_id"])
+	suite.Assert().Contains(fields, "latency")
+}
+
+func (suite *AccessLogTestSuite) TestClientErrorsAreWarnings() {
+	w := suite.serve(httptest.NewRequest(http.MethodGet, "/items/7", nil))
+	suite.Require().Equal(http.StatusUnauthorized, w.Code)
+
+	entries := suite.logs.FilterMessage("request").All()
+	suite.Require().Len(entries, 1)
+	suite.Assert().Equal(zapcore.WarnLevel, entries[0].Level)
+	suite.Assert().NotContains(entries[0].ContextMap(), "user_id")
+}
+
+func (suite *AccessLogTestSuite) TestTagsTracedRequests() {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/items/7", nil)
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), sc))
+	suite.serve(req)
+
+	entries := suite.logs.FilterMessage("request").All()
+	suite.Require().Len(entries, 1)
+	fields := entries[0].ContextMap()
+	suite.Assert().Equal("4bf92f3577b34da6a3ce929d0e0e4736", fields["trace_id"])
+	suite.Assert().Equal("00f067aa0ba902b7", fields["span_id"])
+}
+
+func (suite *AccessLogTestSuite) TestSkipsPaths() {
+	suite.serve(httptest.NewRequest(http.MethodGet, "/health", nil))
+	suite.Assert().Zero(suite.logs.Len())
+}
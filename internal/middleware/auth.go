@@ -1,15 +1,16 @@
 package middleware
 
 import (
-	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"go-web-template/internal/apperr"
 	"go-web-template/internal/config"
+	"go-web-template/internal/reqctx"
+	"go-web-template/internal/utils"
 	"io"
 	"net/http"
 	"strconv"
@@ -19,13 +20,9 @@ import (
 	"go.uber.org/zap"
 )
 
-// Context key for user ID
-type ctxKey int
-
-const userIDKey ctxKey = 0
-
 var (
-	ErrTokenExpired = errors.New("token has expired")
+	ErrTokenExpired    = errors.New("token has expired")
+	ErrUnauthenticated = apperr.Unauthenticated("unauthenticated")
 )
 
 type WebClientUserClaim struct {
@@ -75,7 +72,7 @@ func (m *AuthMiddleware) WebClientAuthentication(next http.Handler) http.Handler
 				userID, err := m.decodeWebClientUserID(claims.UserID)
 				if err == nil {
 					// Store user ID in context and continue
-					ctx := context.WithValue(r.Context(), userIDKey, userID)
+					ctx := withUserID(r.Context(), userID)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -85,30 +82,30 @@ func (m *AuthMiddleware) WebClientAuthentication(next http.Handler) http.Handler
 		// If access missing/expired, try refresh token
 		refreshCookie, err := r.Cookie("refresh")
 		if err != nil || refreshCookie.Value == "" {
-			m.respondUnauthorized(w, "unauthenticated")
+			utils.RespondProblem(w, r, ErrUnauthenticated)
 			return
 		}
 
 		rClaims, err := m.decodeWebClientToken(refreshCookie.Value, "refresh")
 		if err != nil {
-			m.respondUnauthorized(w, "unauthenticated")
+			utils.RespondProblem(w, r, ErrUnauthenticated)
 			return
 		}
 
 		userID, err := m.decodeWebClientUserID(rClaims.UserID)
 		if err != nil {
-			m.respondUnauthorized(w, "unauthenticated")
+			utils.RespondProblem(w, r, ErrUnauthenticated)
 			return
 		}
 
 		// Issue new access token
 		if err := m.issueAccessCookie(w, userID); err != nil {
-			m.respondUnauthorized(w, "unauthenticated")
+			utils.RespondProblem(w, r, ErrUnauthenticated)
 			return
 		}
 
 		// Store user ID in context and continue
-		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		ctx := withUserID(r.Context(), userID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -353,20 +350,8 @@ func (m *AuthMiddleware) decodeWebClientToken(tokenString string, cookieType str
 	return nil, nil
 }
 
-func (m *AuthMiddleware) respondUnauthorized(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-
-	err := json.NewEncoder(w).Encode(map[string]string{
-		"title":   "Unauthorized",
-		"message": message,
-	})
-	if err != nil {
-		m.logger.Error("failed to encode unauthorized response", zap.Error(err))
-	}
-}
-
+// GetUserID returns the authenticated user; code that only has the request context,
+// such as services, uses reqctx.UserID.
 func GetUserID(r *http.Request) (int64, bool) {
-	userID, ok := r.Context().Value(userIDKey).(int64)
-	return userID, ok
+	return reqctx.UserID(r.Context())
 }
@@ -192,11 +192,12 @@ func (suite *AuthMiddlewareTestSuite) TestWebClientAuthentication_BothTokensExpi
 	// Should return unauthorized
 	suite.Equal(http.StatusUnauthorized, w.Code)
 
-	var response map[string]string
+	var response map[string]any
 	err := json.NewDecoder(w.Body).Decode(&response)
 	suite.NoError(err)
 	suite.Equal("Unauthorized", response["title"])
-	suite.Equal("unauthenticated", response["message"])
+	suite.Equal("unauthenticated", response["detail"])
+	suite.Equal("unauthenticated", response["code"])
 }
 
 func (suite *AuthMiddlewareTestSuite) TestWebClientAuthentication_NoTokens() {
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-chi/cors"
+)
+
+// CORS applies the API's CORS policy. The allowed origins can be replaced while the
+// server runs, e.g. when the configuration is reloaded.
+type CORS struct {
+	current atomic.Pointer[cors.Cors]
+}
+
+func NewCORS(allowedOrigins []string) *CORS {
+	c := &CORS{}
+	c.SetAllowedOrigins(allowedOrigins)
+	return c
+}
+
+// SetAllowedOrigins takes effect for the next request.
+func (c *CORS) SetAllowedOrigins(allowedOrigins []string) {
+	c.current.Store(cors.New(cors.Options{
+		AllowedOrigins:   allowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"Link"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}))
+}
+
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.current.Load().Handler(next).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"go-web-template/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CORSTestSuite struct {
+	suite.Suite
+}
+
+func TestCORSTestSuite(t *testing.T) {
+	suite.Run(t, new(CORSTestSuite))
+}
+
+func (suite *CORSTestSuite) allowedOrigin(handler http.Handler, origin string) string {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", origin)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w.Header().Get("Access-Control-Allow-Origin")
+}
+
+func (suite *CORSTestSuite) TestSetAllowedOrigins() {
+	cors := middleware.NewCORS([]string{"https://app.example.com"})
+	handler := cors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	suite.Equal("https://app.example.com", suite.allowedOrigin(handler, "https://app.example.com"))
+	suite.Empty(suite.allowedOrigin(handler, "https://new.example.com"))
+
+	cors.SetAllowedOrigins([]string{"https://*.example.com"})
+
+	suite.Equal("https://new.example.com", suite.allowedOrigin(handler, "https://new.example.com"))
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/tenant"
+	"go-web-template/internal/utils"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// PermissionChecker reports whether a user holds a named permission through their global role.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID int64, permission string) (bool, error)
+}
+
+// OrganizationPermissionChecker reports whether a user holds a named permission through
+// their role in a specific organization.
+type OrganizationPermissionChecker interface {
+	HasOrganizationPermission(ctx context.Context, organizationID, userID int64, permission string) (bool, error)
+}
+
+type PermissionMiddlewareInterface interface {
+	Require(permission string) func(next http.Handler) http.Handler
+}
+
+type PermissionMiddleware struct {
+	checker    PermissionChecker
+	orgChecker OrganizationPermissionChecker
+	logger     *zap.Logger
+}
+
+func NewPermissionMiddleware(
+	checker PermissionChecker,
+	orgChecker OrganizationPermissionChecker,
+	logger *zap.Logger,
+) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		checker:    checker,
+		orgChecker: orgChecker,
+		logger:     logger,
+	}
+}
+
+var _ PermissionMiddlewareInterface = (*PermissionMiddleware)(nil)
+
+// Require must run after WebClientAuthentication, since it reads the user ID from the request context.
+// When the request has been resolved to an organization, the user's role in that organization is
+// checked instead of their global role.
+func (m *PermissionMiddleware) Require(permission string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserID(r)
+			if !ok {
+				utils.RespondProblem(w, r, ErrUnauthenticated)
+				return
+			}
+
+			var allowed bool
+			var err error
+			if organizationID, ok := tenant.OrganizationID(r.Context()); ok {
+				allowed, err = m.orgChecker.HasOrganizationPermission(r.Context(), organizationID, userID, permission)
+			} else {
+				allowed, err = m.checker.HasPermission(r.Context(), userID, permission)
+			}
+			if err != nil {
+				m.logger.Error("failed to check permission",
+					zap.Int64("user_id", userID),
+					zap.String("permission", permission),
+					zap.Error(err),
+				)
+				utils.RespondProblem(w, r, apperr.Internal(err))
+				return
+			}
+
+			if !allowed {
+				utils.RespondProblem(w, r, apperr.Forbidden("missing permission: "+permission).WithCode("missing_permission"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
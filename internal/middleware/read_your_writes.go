@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"go-web-template/internal/config"
+	"go-web-template/internal/store"
+	"math"
+	"net/http"
+)
+
+// readYourWritesCookie marks a client that wrote within the stickiness window.
+const readYourWritesCookie = "rw_primary"
+
+// ReadYourWrites keeps a client's reads on the primary database for
+// DB_REPLICA_STICKINESS after it wrote, so it sees its own changes despite replication
+// lag. A response to a request that wrote carries a short-lived cookie, and requests that
+// send it back read from the primary, whichever instance serves them and whether or not
+// the client is signed in.
+//
+// Clients that don't keep cookies, and writes made after the response headers were sent,
+// are not pinned; such code can use store.WithPrimary for the reads that must see a write.
+func ReadYourWrites(cfg *config.Config) func(http.Handler) http.Handler {
+	maxAge := int(math.Ceil(cfg.Database.ReplicaStickiness.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if _, err := r.Cookie(readYourWritesCookie); err == nil {
+				ctx = store.WithPrimary(ctx)
+			}
+			ctx, marker := store.WithWriteMarker(ctx)
+
+			ww := &readYourWritesWriter{ResponseWriter: w, marker: marker, cookie: &http.Cookie{
+				Name:     readYourWritesCookie,
+				Value:    "1",
+				Path:     "/",
+				Domain:   cfg.App.CookieDomain,
+				MaxAge:   maxAge,
+				Secure:   cfg.App.Environment == "production",
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			}}
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		})
+	}
+}
+
+// readYourWritesWriter sets the cookie when the headers are written, if the request
+// wrote by then.
+type readYourWritesWriter struct {
+	http.ResponseWriter
+	marker      *store.WriteMarker
+	cookie      *http.Cookie
+	wroteHeader bool
+}
+
+func (w *readYourWritesWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.marker.Marked() {
+			http.SetCookie(w.ResponseWriter, w.cookie)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *readYourWritesWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush.
+func (w *readYourWritesWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"go-web-template/internal/config"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReadYourWritesTestSuite struct {
+	suite.Suite
+	handler http.Handler
+}
+
+func TestReadYourWritesTestSuite(t *testing.T) {
+	suite.Run(t, new(ReadYourWritesTestSuite))
+}
+
+func (suite *ReadYourWritesTestSuite) SetupTest() {
+	cfg := &config.Config{Database: config.DatabaseConfig{ReplicaStickiness: 1500 * time.Millisecond}}
+	suite.handler = middleware.ReadYourWrites(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			store.MarkWrite(r.Context())
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+}
+
+func (suite *ReadYourWritesTestSuite) serve(method string) *http.Response {
+	w := httptest.NewRecorder()
+	suite.handler.ServeHTTP(w, httptest.NewRequest(method, "/", nil))
+	return w.Result()
+}
+
+func (suite *ReadYourWritesTestSuite) TestWriteSetsCookie() {
+	cookies := suite.serve(http.MethodPost).Cookies()
+	suite.Require().Len(cookies, 1)
+	suite.Equal("rw_primary", cookies[0].Name)
+	suite.Equal(2, cookies[0].MaxAge, "the window is rounded up to whole seconds")
+	suite.True(cookies[0].HttpOnly)
+}
+
+func (suite *ReadYourWritesTestSuite) TestReadSetsNoCookie() {
+	suite.Empty(suite.serve(http.MethodGet).Cookies())
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"go-web-template/internal/reqctx"
+	"net"
+	"net/http"
+)
+
+// RequestMetadata stores the client IP in the request context, for reqctx.ClientIP, so
+// services can read it without the *http.Request. It should run after chi's RealIP
+// middleware.
+func RequestMetadata(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			ip = host
+		}
+
+		next.ServeHTTP(w, r.WithContext(reqctx.WithClientIP(r.Context(), ip)))
+	})
+}
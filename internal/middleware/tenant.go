@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/tenant"
+	"go-web-template/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	OrganizationHeader    = "X-Organization-ID"
+	OrganizationPathParam = "orgID"
+)
+
+// MembershipChecker reports whether a user belongs to an organization.
+type MembershipChecker interface {
+	IsMember(ctx context.Context, organizationID, userID int64) (bool, error)
+}
+
+type TenantMiddlewareInterface interface {
+	ResolveOrganization(next http.Handler) http.Handler
+}
+
+type TenantMiddleware struct {
+	checker MembershipChecker
+	logger  *zap.Logger
+}
+
+func NewTenantMiddleware(checker MembershipChecker, logger *zap.Logger) *TenantMiddleware {
+	return &TenantMiddleware{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+var _ TenantMiddlewareInterface = (*TenantMiddleware)(nil)
+
+// ResolveOrganization reads the active organization from the {orgID} path segment,
+// falling back to the X-Organization-ID header, and verifies the caller is a member.
+// It must run after WebClientAuthentication.
+func (m *TenantMiddleware) ResolveOrganization(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := chi.URLParam(r, OrganizationPathParam)
+		if raw == "" {
+			raw = r.Header.Get(OrganizationHeader)
+		}
+		if raw == "" {
+			utils.RespondProblem(w, r, apperr.BadRequest("organization not specified").WithCode("organization_required"))
+			return
+		}
+
+		organizationID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || organizationID < 1 {
+			utils.RespondProblem(w, r, apperr.BadRequest("invalid organization id"))
+			return
+		}
+
+		userID, ok := GetUserID(r)
+		if !ok {
+			utils.RespondProblem(w, r, ErrUnauthenticated)
+			return
+		}
+
+		member, err := m.checker.IsMember(r.Context(), organizationID, userID)
+		if err != nil {
+			m.logger.Error("failed to check organization membership",
+				zap.Int64("organization_id", organizationID),
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			utils.RespondProblem(w, r, apperr.Internal(err))
+			return
+		}
+		if !member {
+			utils.RespondProblem(w, r, apperr.Forbidden("not a member of this organization").WithCode("not_organization_member"))
+			return
+		}
+
+		ctx := tenant.WithOrganizationID(r.Context(), organizationID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
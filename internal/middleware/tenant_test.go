@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"context"
+	"go-web-template/internal/config"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/tenant"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// memberOf treats the user as a member of exactly one organization
+type memberOf int64
+
+func (m memberOf) IsMember(_ context.Context, organizationID, _ int64) (bool, error) {
+	return organizationID == int64(m), nil
+}
+
+type TenantMiddlewareTestSuite struct {
+	suite.Suite
+	auth   *middleware.AuthMiddleware
+	router *chi.Mux
+}
+
+func (suite *TenantMiddlewareTestSuite) SetupTest() {
+	cfg := &config.Config{}
+	cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+
+	logger := zap.NewNop()
+	suite.auth = middleware.NewAuthMiddleware(cfg, logger, time.Minute, time.Hour, time.Hour)
+	tenants := middleware.NewTenantMiddleware(memberOf(5), logger)
+
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		organizationID, ok := tenant.OrganizationID(r.Context())
+		suite.True(ok)
+		_, _ = w.Write([]byte(strconv.FormatInt(organizationID, 10)))
+	})
+
+	suite.router = chi.NewRouter()
+	suite.router.Use(suite.auth.WebClientAuthentication)
+	suite.router.With(tenants.ResolveOrganization).Get("/orgs/{orgID}/things", echo)
+	suite.router.With(tenants.ResolveOrganization).Get("/things", echo)
+}
+
+func TestTenantMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantMiddlewareTestSuite))
+}
+
+func (suite *TenantMiddlewareTestSuite) request(target string) *http.Request {
+	accessToken, _, err := suite.auth.GenerateLoginTokens(1, false)
+	suite.Require().NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.AddCookie(&http.Cookie{Name: "access", Value: accessToken})
+	return req
+}
+
+func (suite *TenantMiddlewareTestSuite) TestResolveOrganization_FromPath() {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, suite.request("/orgs/5/things"))
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("5", w.Body.String())
+}
+
+func (suite *TenantMiddlewareTestSuite) TestResolveOrganization_FromHeader() {
+	req := suite.request("/things")
+	req.Header.Set(middleware.OrganizationHeader, "5")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("5", w.Body.String())
+}
+
+func (suite *TenantMiddlewareTestSuite) TestResolveOrganization_NotMember() {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, suite.request("/orgs/6/things"))
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
+
+func (suite *TenantMiddlewareTestSuite) TestResolveOrganization_Missing() {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, suite.request("/things"))
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,147 @@
+// Package migrate applies the migrations embedded in the binary with goose. Every run
+// that changes the schema holds a Postgres advisory lock, so deploys that start at the
+// same time wait for each other instead of applying a migration twice.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go-web-template/migrations"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// New loads the embedded SQL migrations and the registered Go migrations for db, a
+// database/sql handle such as stdlib.OpenDBFromPool(pool). extra adds Go migrations of
+// the caller's own, e.g. a test fixture.
+func New(db *sql.DB, extra ...*goose.Migration) (*Migrator, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration lock: %w", err)
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS,
+		goose.WithSessionLocker(locker),
+		goose.WithGoMigrations(slices.Concat(migrations.Go(), extra)...),
+		goose.WithDisableGlobalRegistry(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return &Migrator{provider: provider}, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+// UpTo applies the pending migrations up to and including version.
+func (m *Migrator) UpTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	return m.provider.UpTo(ctx, version)
+}
+
+// Down rolls back the latest applied migration.
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+// DownTo rolls back the migrations newer than version; 0 rolls back all of them.
+func (m *Migrator) DownTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	return m.provider.DownTo(ctx, version)
+}
+
+// Redo rolls back the latest applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	version, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		return nil, errors.New("no migration has been applied")
+	}
+
+	down, err := m.provider.ApplyVersion(ctx, version, false)
+	if err != nil {
+		return nil, err
+	}
+	up, err := m.provider.ApplyVersion(ctx, version, true)
+	if err != nil {
+		return []*goose.MigrationResult{down}, err
+	}
+	return []*goose.MigrationResult{down, up}, nil
+}
+
+// Version returns the newest migration applied to the database, 0 for none.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	return m.provider.GetDBVersion(ctx)
+}
+
+// Check returns an error if the database is behind the newest embedded migration, i.e.
+// older than the code expects.
+func (m *Migrator) Check(ctx context.Context) error {
+	current, latest, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return err
+	}
+	if current < latest {
+		return fmt.Errorf("database is at migration %d, expected %d", current, latest)
+	}
+	return nil
+}
+
+// Status is the state of every embedded migration, as printed by migrate status -json.
+type Status struct {
+	CurrentVersion int64             `json:"current_version"`
+	LatestVersion  int64             `json:"latest_version"`
+	Pending        int               `json:"pending"`
+	Migrations     []MigrationStatus `json:"migrations"`
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	State     string     `json:"state"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	current, latest, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{CurrentVersion: current, LatestVersion: latest, Migrations: make([]MigrationStatus, len(results))}
+	for i, result := range results {
+		migration := MigrationStatus{
+			Version: result.Source.Version,
+			Name:    filepath.Base(result.Source.Path),
+			Type:    string(result.Source.Type),
+			State:   string(result.State),
+		}
+		if result.State == goose.StateApplied {
+			appliedAt := result.AppliedAt
+			migration.AppliedAt = &appliedAt
+		} else {
+			status.Pending++
+		}
+		status.Migrations[i] = migration
+	}
+	return status, nil
+}
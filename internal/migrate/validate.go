@@ -0,0 +1,108 @@
+package migrate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+// Validate checks the SQL migrations in fsys without running them: every file is named
+// <version>_<name>.sql with a unique version, has one Up and one Down section in that
+// order, and balances its StatementBegin and StatementEnd annotations. goMigrations must
+// not reuse a version either. It returns every problem found, one per line.
+func Validate(fsys fs.FS, goMigrations ...*goose.Migration) error {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return err
+	}
+
+	var problems []error
+	versions := make(map[int64]string, len(files))
+	for _, file := range files {
+		version, err := goose.NumericComponent(file)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: not named <version>_<name>.sql", file))
+			continue
+		}
+		if other, ok := versions[version]; ok {
+			problems = append(problems, fmt.Errorf("%s: version %d is also used by %s", file, version, other))
+		}
+		versions[version] = file
+
+		for _, problem := range validateSQL(fsys, file) {
+			problems = append(problems, fmt.Errorf("%s: %s", file, problem))
+		}
+	}
+	for _, m := range goMigrations {
+		if other, ok := versions[m.Version]; ok {
+			problems = append(problems, fmt.Errorf("%s: version %d is also used by %s", m.Source, m.Version, other))
+		}
+		versions[m.Version] = m.Source
+	}
+	return errors.Join(problems...)
+}
+
+func validateSQL(fsys fs.FS, file string) []string {
+	f, err := fsys.Open(file)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	defer f.Close()
+
+	var problems []string
+	var up, down, inStatement bool
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		annotation, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "-- +goose ")
+		if !ok {
+			continue
+		}
+
+		switch annotation = strings.TrimSpace(annotation); annotation {
+		case "Up":
+			if up {
+				problems = append(problems, fmt.Sprintf("line %d: second Up section", n))
+			}
+			up = true
+		case "Down":
+			if !up {
+				problems = append(problems, fmt.Sprintf("line %d: Down section before the Up section", n))
+			}
+			if down {
+				problems = append(problems, fmt.Sprintf("line %d: second Down section", n))
+			}
+			down = true
+		case "StatementBegin":
+			if inStatement {
+				problems = append(problems, fmt.Sprintf("line %d: StatementBegin inside another statement", n))
+			}
+			inStatement = true
+		case "StatementEnd":
+			if !inStatement {
+				problems = append(problems, fmt.Sprintf("line %d: StatementEnd without StatementBegin", n))
+			}
+			inStatement = false
+		case "NO TRANSACTION", "ENVSUB ON", "ENVSUB OFF":
+		default:
+			problems = append(problems, fmt.Sprintf("line %d: unknown annotation %q", n, annotation))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return append(problems, err.Error())
+	}
+
+	if inStatement {
+		problems = append(problems, "StatementBegin without StatementEnd")
+	}
+	if !up {
+		problems = append(problems, "no -- +goose Up section")
+	}
+	if !down {
+		problems = append(problems, "no -- +goose Down section, so it can't be rolled back")
+	}
+	return problems
+}
@@ -0,0 +1,64 @@
+package migrate_test
+
+import (
+	"go-web-template/internal/migrate"
+	"go-web-template/migrations"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (suite *ValidateTestSuite) TestEmbeddedMigrationsAreValid() {
+	suite.NoError(migrate.Validate(migrations.FS, migrations.Go()...))
+}
+
+func (suite *ValidateTestSuite) TestReportsGoMigrationReusingAVersion() {
+	fsys := fstest.MapFS{
+		"00001_create.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n-- +goose Down\nSELECT 1;\n")},
+	}
+
+	err := migrate.Validate(fsys,
+		migrations.NewGoMigration("00001_backfill.go", nil, nil),
+		migrations.NewGoMigration("00002_backfill.go", nil, nil),
+	)
+
+	suite.EqualError(err, "00001_backfill.go: version 1 is also used by 00001_create.sql")
+}
+
+func (suite *ValidateTestSuite) TestReportsEveryProblem() {
+	fsys := fstest.MapFS{
+		"00001_ok.sql": {Data: []byte(`-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION f();
+`)},
+		"00002_no_down.sql":      {Data: []byte("-- +goose Up\nCREATE TABLE t (id int);\n")},
+		"00002_duplicate.sql":    {Data: []byte("-- +goose Up\nSELECT 1;\n-- +goose Down\nSELECT 1;\n")},
+		"00003_unbalanced.sql":   {Data: []byte("-- +goose Up\n-- +goose StatementBegin\nSELECT 1;\n-- +goose Down\n")},
+		"00004_typo.sql":         {Data: []byte("-- +goose up\nSELECT 1;\n-- +goose Down\n")},
+		"create_posts_table.sql": {Data: []byte("-- +goose Up\n-- +goose Down\n")},
+	}
+
+	err := migrate.Validate(fsys)
+
+	suite.Require().Error(err)
+	suite.Equal(`00002_no_down.sql: version 2 is also used by 00002_duplicate.sql
+00002_no_down.sql: no -- +goose Down section, so it can't be rolled back
+00003_unbalanced.sql: StatementBegin without StatementEnd
+00004_typo.sql: line 1: unknown annotation "up"
+00004_typo.sql: line 3: Down section before the Up section
+00004_typo.sql: no -- +goose Up section
+create_posts_table.sql: not named <version>_<name>.sql`, err.Error())
+}
@@ -0,0 +1,264 @@
+// Package openapi builds an OpenAPI 3.1 document from the operations each handler
+// declares next to its routes, with schemas reflected from the request and response types.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-web-template/internal/utils"
+)
+
+const Version = "3.1.0"
+
+const (
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+	cookieAuthScheme   = "cookieAuth"
+)
+
+// Operation documents one route registered by a handler's Routes. Path is relative to
+// the handler's mount point and uses chi's {param} syntax, which OpenAPI shares.
+type Operation struct {
+	Method      string
+	Path        string
+	ID          string
+	Summary     string
+	Description string
+	// Request and Response are zero values of the body types; nil means no body
+	Request  any
+	Response any
+	// Status is the success status, 200 when unset
+	Status int
+	// Auth marks routes behind the session cookie, and Permission the RBAC permission
+	// they additionally require
+	Auth       bool
+	Permission string
+	Query      []Param
+}
+
+// Param is a query parameter. Type is a JSON Schema type, e.g. "integer".
+type Param struct {
+	Name        string
+	Type        string
+	Format      string
+	Description string
+}
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps a lower-case HTTP method to its operation.
+type PathItem map[string]*OperationObject
+
+type OperationObject struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []ParameterObject     `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Permission  string                `json:"x-required-permission,omitempty"`
+}
+
+type ParameterObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Builder accumulates operations into a Document.
+type Builder struct {
+	doc      *Document
+	registry *schemaRegistry
+}
+
+func NewBuilder(info Info) *Builder {
+	registry := newSchemaRegistry()
+	return &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   make(map[string]*PathItem),
+			Components: Components{
+				Schemas: registry.schemas,
+				SecuritySchemes: map[string]SecurityScheme{
+					cookieAuthScheme: {Type: "apiKey", In: "cookie", Name: "access"},
+				},
+			},
+		},
+		registry: registry,
+	}
+}
+
+// Add documents ops mounted at prefix and groups them under tag.
+func (b *Builder) Add(prefix, tag string, ops ...Operation) *Builder {
+	for _, op := range ops {
+		path := JoinPath(prefix, op.Path)
+		item, ok := b.doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			b.doc.Paths[path] = item
+		}
+
+		method := strings.ToLower(op.Method)
+		if _, dup := (*item)[method]; dup {
+			panic(fmt.Sprintf("openapi: %s %s documented twice", op.Method, path))
+		}
+		(*item)[method] = b.operation(path, tag, op)
+	}
+	return b
+}
+
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+func (b *Builder) operation(path, tag string, op Operation) *OperationObject {
+	obj := &OperationObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        []string{tag},
+		Responses:   make(map[string]Response),
+		Permission:  op.Permission,
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		obj.Parameters = append(obj.Parameters, ParameterObject{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int64"},
+		})
+	}
+	for _, param := range op.Query {
+		obj.Parameters = append(obj.Parameters, ParameterObject{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Schema:      &Schema{Type: param.Type, Format: param.Format},
+		})
+	}
+
+	if op.Request != nil {
+		obj.RequestBody = &RequestBody{
+			Required: true,
+			Content:  b.content(jsonContentType, op.Request),
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	if op.Response != nil {
+		success.Content = b.content(jsonContentType, op.Response)
+	}
+	obj.Responses[strconv.Itoa(status)] = success
+
+	problem := b.content(problemContentType, utils.ProblemDetails{})
+	if op.Request != nil {
+		obj.Responses["400"] = Response{Description: "Malformed request body", Content: problem}
+		obj.Responses["422"] = Response{Description: "Validation failed", Content: problem}
+	}
+	if op.Auth {
+		obj.Security = []map[string][]string{{cookieAuthScheme: {}}}
+		obj.Responses["401"] = Response{Description: "Not authenticated", Content: problem}
+	}
+	if op.Permission != "" {
+		obj.Responses["403"] = Response{Description: "Missing permission " + op.Permission, Content: problem}
+	}
+	obj.Responses["default"] = Response{Description: "Error", Content: problem}
+
+	return obj
+}
+
+func (b *Builder) content(contentType string, v any) map[string]MediaType {
+	return map[string]MediaType{
+		contentType: {Schema: b.registry.schemaFor(reflect.TypeOf(v))},
+	}
+}
+
+// JoinPath joins a mount prefix and a route path the way chi reports them, without
+// the trailing slash of a subrouter's root route.
+func JoinPath(prefix, path string) string {
+	joined := strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+	if len(joined) > 1 {
+		joined = strings.TrimSuffix(joined, "/")
+	}
+	return joined
+}
+
+// Routes lists the documented operations as "METHOD /path", sorted.
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, item := range d.Paths {
+		for method := range *item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// Handler serves the document as JSON. It is marshaled once, up front.
+func Handler(doc *Document) http.HandlerFunc {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("openapi: failed to marshal document: %v", err))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", jsonContentType)
+		_, _ = w.Write(body)
+	}
+}
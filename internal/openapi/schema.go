@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"go-web-template/internal/jsonfield"
+	"reflect"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema (draft 2020-12, as used by OpenAPI 3.1) the
+// generator emits. Type is a string, or a list of strings for nullable values.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// schemaRegistry turns Go types into schemas, registering named structs once under
+// components/schemas and referring to them by $ref.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	switch {
+	case t == nil:
+		return &Schema{}
+	case t == jsonfield.TimeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == jsonfield.RawMessageType:
+		// Arbitrary JSON
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(r.schemaFor(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaFor(jsonfield.Elem(t))}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return r.refFor(t)
+	default:
+		// Interfaces and anything else accept any JSON value
+		return &Schema{}
+	}
+}
+
+func (r *schemaRegistry) refFor(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.structSchema(t)
+	}
+
+	name, ok := r.names[t]
+	if !ok {
+		name = r.componentName(t)
+		r.names[t] = name
+		// Reserve the name before descending so recursive types terminate
+		r.schemas[name] = &Schema{}
+		*r.schemas[name] = *r.structSchema(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName is the Go type name, qualified with its package when two packages
+// export types of the same name.
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := r.schemas[name]; !taken {
+		return name
+	}
+
+	pkg := t.PkgPath()
+	pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + name
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range jsonfield.Fields(t) {
+		s.Properties[field.Name] = r.schemaFor(field.Type)
+		if !field.OmitEmpty {
+			s.Required = append(s.Required, field.Name)
+		}
+	}
+	return s
+}
+
+func nullable(s *Schema) *Schema {
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+		return s
+	case nil:
+		if s.Ref == "" {
+			// Already accepts any value, including null
+			return s
+		}
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
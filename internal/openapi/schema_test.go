@@ -0,0 +1,88 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"go-web-template/internal/openapi"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type child struct {
+	Name string `json:"name"`
+}
+
+type Base struct {
+	ID int64 `json:"id"`
+}
+
+type sample struct {
+	Base
+	Title     string            `json:"title"`
+	Note      *string           `json:"note"`
+	Optional  *string           `json:"optional,omitempty"`
+	Count     int               `json:"count,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Raw       json.RawMessage   `json:"raw"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels"`
+	Child     *child            `json:"child"`
+	Secret    string            `json:"-"`
+	hidden    string
+}
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (suite *SchemaTestSuite) TestStructFields() {
+	doc := openapi.NewBuilder(openapi.Info{Title: "test", Version: "1"}).
+		Add("/things", "things", openapi.Operation{Method: "GET", Path: "/", ID: "get", Response: sample{}}).
+		Document()
+
+	s := doc.Components.Schemas["sample"]
+	suite.Require().NotNil(s)
+
+	suite.Assert().ElementsMatch([]string{"id", "title", "note", "created_at", "raw", "tags", "labels", "child"}, s.Required)
+	suite.Assert().NotContains(s.Properties, "Secret")
+	suite.Assert().NotContains(s.Properties, "hidden")
+
+	suite.Assert().Equal(&openapi.Schema{Type: "integer", Format: "int64"}, s.Properties["id"])
+	suite.Assert().Equal([]string{"string", "null"}, s.Properties["note"].Type)
+	suite.Assert().Equal("string", s.Properties["optional"].Type)
+	suite.Assert().Equal(&openapi.Schema{Type: "string", Format: "date-time"}, s.Properties["created_at"])
+	suite.Assert().Equal(&openapi.Schema{}, s.Properties["raw"])
+	suite.Assert().Equal("array", s.Properties["tags"].Type)
+	suite.Assert().Equal("string", s.Properties["labels"].AdditionalProperties.Type)
+
+	suite.Require().Len(s.Properties["child"].AnyOf, 2)
+	suite.Assert().Equal("#/components/schemas/child", s.Properties["child"].AnyOf[0].Ref)
+	suite.Assert().Contains(doc.Components.Schemas, "child")
+}
+
+func (suite *SchemaTestSuite) TestOperation() {
+	doc := openapi.NewBuilder(openapi.Info{Title: "test", Version: "1"}).
+		Add("/things", "things", openapi.Operation{
+			Method: "PUT", Path: "/{id}", ID: "update", Request: child{}, Response: child{},
+			Auth: true, Permission: "things:write",
+		}).
+		Document()
+
+	item := doc.Paths["/things/{id}"]
+	suite.Require().NotNil(item)
+	op := (*item)["put"]
+	suite.Require().NotNil(op)
+
+	suite.Assert().Equal("things:write", op.Permission)
+	suite.Require().Len(op.Parameters, 1)
+	suite.Assert().Equal("path", op.Parameters[0].In)
+	suite.Assert().NotNil(op.RequestBody)
+	for _, status := range []string{"200", "400", "401", "403", "422", "default"} {
+		suite.Assert().Contains(op.Responses, status)
+	}
+}
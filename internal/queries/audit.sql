@@ -0,0 +1,28 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor_id, action, target_type, target_id, ip_address, request_id, changes, metadata)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor_id)::bigint IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (sqlc.narg(action)::text IS NULL OR action = sqlc.narg(action))
+  AND (sqlc.narg(target_type)::text IS NULL OR target_type = sqlc.narg(target_type))
+  AND (sqlc.narg(target_id)::bigint IS NULL OR target_id = sqlc.narg(target_id))
+  AND (sqlc.narg(since)::timestamp IS NULL OR created_at >= sqlc.narg(since))
+  AND (sqlc.narg(until)::timestamp IS NULL OR created_at < sqlc.narg(until))
+ORDER BY created_at DESC, id DESC
+    LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*) FROM audit_events
+WHERE (sqlc.narg(actor_id)::bigint IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (sqlc.narg(action)::text IS NULL OR action = sqlc.narg(action))
+  AND (sqlc.narg(target_type)::text IS NULL OR target_type = sqlc.narg(target_type))
+  AND (sqlc.narg(target_id)::bigint IS NULL OR target_id = sqlc.narg(target_id))
+  AND (sqlc.narg(since)::timestamp IS NULL OR created_at >= sqlc.narg(since))
+  AND (sqlc.narg(until)::timestamp IS NULL OR created_at < sqlc.narg(until));
+
+-- name: PurgeAuditEventsBefore :execrows
+DELETE FROM audit_events
+WHERE created_at < sqlc.arg(before);
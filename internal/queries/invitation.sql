@@ -0,0 +1,40 @@
+-- name: CreateInvitation :one
+INSERT INTO invitations (email, role_id, invited_by, expires_at, organization_id)
+VALUES ($1, $2, $3, $4, $5)
+    RETURNING *;
+
+-- name: GetInvitationByID :one
+SELECT * FROM invitations
+WHERE id = $1;
+
+-- name: GetInvitationByIDForUpdate :one
+SELECT * FROM invitations
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: GetPendingInvitationByEmail :one
+SELECT * FROM invitations
+WHERE email = $1 AND organization_id IS NOT DISTINCT FROM $2
+  AND accepted_at IS NULL AND revoked_at IS NULL
+    LIMIT 1;
+
+-- name: ListInvitations :many
+SELECT * FROM invitations
+WHERE organization_id IS NULL
+ORDER BY created_at DESC;
+
+-- name: ListOrganizationInvitations :many
+SELECT * FROM invitations
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeInvitation :execrows
+UPDATE invitations
+SET revoked_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND organization_id IS NOT DISTINCT FROM $2
+  AND accepted_at IS NULL AND revoked_at IS NULL;
+
+-- name: MarkInvitationAccepted :execrows
+UPDATE invitations
+SET accepted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL;
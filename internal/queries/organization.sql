@@ -0,0 +1,67 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name, slug)
+VALUES ($1, $2)
+    RETURNING *;
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations
+WHERE id = $1;
+
+-- name: GetOrganizationBySlug :one
+SELECT * FROM organizations
+WHERE slug = $1;
+
+-- name: ListOrganizationsForUser :many
+SELECT o.id, o.name, o.slug, o.created_at, o.updated_at, m.role_id
+FROM organizations o
+JOIN organization_members m ON m.organization_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.name;
+
+-- name: AddOrganizationMember :one
+INSERT INTO organization_members (organization_id, user_id, role_id)
+VALUES ($1, $2, $3)
+    RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT m.user_id, u.email, u.display_name, m.role_id, m.created_at
+FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1 AND u.deleted_at IS NULL
+ORDER BY m.created_at;
+
+-- name: UpdateOrganizationMemberRole :execrows
+UPDATE organization_members
+SET role_id = $3, updated_at = NOW()
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: LockOrganizationMembersWithRole :many
+SELECT user_id FROM organization_members
+WHERE organization_id = $1 AND role_id = $2
+ORDER BY user_id
+FOR UPDATE;
+
+-- name: RemoveOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: MemberHasPermission :one
+SELECT EXISTS (
+    SELECT 1 FROM organization_members m
+    JOIN role_permissions rp ON rp.role_id = m.role_id
+    JOIN permissions p ON p.id = rp.permission_id
+    WHERE m.organization_id = sqlc.arg(organization_id)
+      AND m.user_id = sqlc.arg(user_id)
+      AND (p.name = sqlc.arg(permission) OR p.name = 'system:superadmin')
+) OR EXISTS (
+    SELECT 1 FROM users u
+    JOIN role_permissions rp ON rp.role_id = u.role_id
+    JOIN permissions p ON p.id = rp.permission_id
+    WHERE u.id = sqlc.arg(user_id)
+      AND u.deleted_at IS NULL
+      AND p.name = 'system:superadmin'
+) AS allowed;
@@ -2,8 +2,8 @@
 SELECT * FROM roles WHERE name = $1;
 
 -- name: CreateRole :one
-INSERT INTO roles (name, is_default, description)
-VALUES ($1, $2, $3)
+INSERT INTO roles (name, is_default, description, organization_scoped)
+VALUES ($1, $2, $3, $4)
     RETURNING *;
 
 -- name: GetPermissionByName :one
@@ -17,4 +17,66 @@ VALUES ($1, $2)
 -- name: AssignPermissionToRole :exec
 INSERT INTO role_permissions (role_id, permission_id)
 VALUES ($1, $2)
-    ON CONFLICT DO NOTHING;
\ No newline at end of file
+    ON CONFLICT DO NOTHING;
+
+-- name: GetRoleByID :one
+SELECT * FROM roles WHERE id = $1;
+
+-- name: ListRolePermissionNames :many
+SELECT p.name FROM permissions p
+JOIN role_permissions rp ON rp.permission_id = p.id
+WHERE rp.role_id = $1
+ORDER BY p.name;
+
+-- name: CanAssignOrganizationRole :one
+SELECT EXISTS (
+    SELECT 1 FROM roles r
+    WHERE r.id = sqlc.arg(role_id)
+      AND r.organization_scoped
+      AND NOT EXISTS (
+          SELECT 1 FROM role_permissions rp
+          JOIN permissions p ON p.id = rp.permission_id
+          WHERE rp.role_id = r.id
+            AND (p.name = 'system:superadmin' OR (
+                sqlc.narg(actor_id)::bigint IS NOT NULL
+                AND NOT EXISTS (
+                    SELECT 1 FROM organization_members m
+                    JOIN role_permissions mrp ON mrp.role_id = m.role_id
+                    JOIN permissions mp ON mp.id = mrp.permission_id
+                    WHERE m.organization_id = sqlc.arg(organization_id)
+                      AND m.user_id = sqlc.narg(actor_id)
+                      AND mp.name IN (p.name, 'system:superadmin')
+                )
+                AND NOT EXISTS (
+                    SELECT 1 FROM users u
+                    JOIN role_permissions urp ON urp.role_id = u.role_id
+                    JOIN permissions up ON up.id = urp.permission_id
+                    WHERE u.id = sqlc.narg(actor_id)
+                      AND u.deleted_at IS NULL
+                      AND up.name = 'system:superadmin'
+                )
+            ))
+      )
+) AS assignable;
+
+-- name: HoldsRolePermissions :one
+SELECT NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    JOIN permissions p ON p.id = rp.permission_id
+    WHERE rp.role_id = sqlc.arg(role_id)
+      AND NOT EXISTS (
+          SELECT 1 FROM organization_members m
+          JOIN role_permissions mrp ON mrp.role_id = m.role_id
+          JOIN permissions mp ON mp.id = mrp.permission_id
+          WHERE m.organization_id = sqlc.arg(organization_id)
+            AND m.user_id = sqlc.arg(actor_id)
+            AND mp.name IN (p.name, 'system:superadmin')
+      )
+) OR EXISTS (
+    SELECT 1 FROM users u
+    JOIN role_permissions urp ON urp.role_id = u.role_id
+    JOIN permissions up ON up.id = urp.permission_id
+    WHERE u.id = sqlc.arg(actor_id)
+      AND u.deleted_at IS NULL
+      AND up.name = 'system:superadmin'
+) AS holds;
@@ -10,7 +10,7 @@ WHERE deleted_at IS NULL;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 AND deleted_at IS NULL;
+WHERE lower(email) = lower(sqlc.arg(email)) AND deleted_at IS NULL;
 
 -- name: CreateUser :one
 INSERT INTO users (email, password, display_name, role_id)
@@ -24,4 +24,14 @@ WHERE id = $1 AND deleted_at IS NULL;
 -- name: GetDefaultRole :one
 SELECT * FROM roles
 WHERE is_default = true
-    LIMIT 1;
\ No newline at end of file
+    LIMIT 1;
+
+-- name: UserHasPermission :one
+SELECT EXISTS (
+    SELECT 1 FROM users u
+    JOIN role_permissions rp ON rp.role_id = u.role_id
+    JOIN permissions p ON p.id = rp.permission_id
+    WHERE u.id = sqlc.arg(user_id)
+      AND u.deleted_at IS NULL
+      AND (p.name = sqlc.arg(permission) OR p.name = 'system:superadmin')
+);
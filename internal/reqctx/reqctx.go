@@ -0,0 +1,35 @@
+// Package reqctx carries who made a request, and from where, through request-scoped
+// contexts. The HTTP middleware sets the values; services read them without depending
+// on the middleware.
+package reqctx
+
+import "context"
+
+type ctxKey int
+
+const (
+	userIDKey ctxKey = iota
+	clientIPKey
+)
+
+// WithUserID returns a copy of ctx acting for the given authenticated user.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID returns the authenticated user, if the request has one.
+func UserID(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey).(int64)
+	return userID, ok
+}
+
+// WithClientIP returns a copy of ctx recording the IP the request came from.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// ClientIP returns the IP recorded by WithClientIP, or an empty string.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
@@ -0,0 +1,128 @@
+// Package server assembles the HTTP router from the domain handlers, so the API binary
+// and tests serve exactly the same routes.
+package server
+
+import (
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/config"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/health"
+	"go-web-template/internal/metrics"
+	"go-web-template/internal/openapi"
+	"go-web-template/internal/store"
+	"go-web-template/internal/tracing"
+	"go-web-template/internal/utils"
+	"go-web-template/pkg/logging"
+	"net/http"
+	"time"
+
+	mWare "go-web-template/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+type Handlers struct {
+	Auth         *auth.AuthHandler
+	User         *user.UserHandler
+	Invitation   *invitation.InvitationHandler
+	Organization *organization.OrganizationHandler
+	Audit        *audit.AuditHandler
+	Health       *health.Registry
+	CORS         *mWare.CORS
+}
+
+func NewRouter(cfg *config.Config, h *Handlers, authMiddleware *mWare.AuthMiddleware, m *metrics.Metrics, logger *zap.Logger) *chi.Mux {
+	r := chi.NewRouter()
+
+	// Middleware
+	r.Use(middleware.RequestID)
+	r.Use(middleware.RealIP)
+	r.Use(mWare.RequestMetadata)
+	r.Use(tracing.Middleware)
+	r.Use(mWare.AccessLog(logger, cfg.App.AccessLogSkipPaths))
+	r.Use(m.Middleware)
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.Timeout(60 * time.Second))
+
+	// CORS
+	r.Use(h.CORS.Handler)
+
+	// Reads stay on the primary for a while after a client wrote
+	if len(cfg.Database.ReplicaURLs) > 0 && cfg.Database.ReplicaStickiness > 0 {
+		r.Use(mWare.ReadYourWrites(cfg))
+	}
+
+	r.NotFound(m.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		utils.RespondProblem(w, r, apperr.NotFound("route not found"))
+	}))
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		utils.RespondProblem(w, r, apperr.New(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed"))
+	})
+
+	// Kept for existing probes; /livez and /readyz report dependencies
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("OK")); err != nil {
+			logging.FromContext(r.Context()).Debug("failed to write health response", zap.Error(err))
+		}
+	})
+	r.Get("/livez", h.Health.LivenessHandler())
+	r.Get("/readyz", h.Health.ReadinessHandler())
+
+	// API routes
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/openapi.json", openapi.Handler(Spec(h)))
+		r.Get("/docs", openapi.DocsHandler())
+		r.Method(http.MethodGet, "/docs/assets/*", http.StripPrefix("/api/docs/assets/", openapi.DocsAssetsHandler()))
+
+		// Public routes
+		r.Mount("/auth", h.Auth.Routes())
+		r.Mount("/invitations", h.Invitation.Routes())
+
+		// Protected routes
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.WebClientAuthentication)
+
+			r.Mount("/users", h.User.Routes())
+			r.Mount("/organizations", h.Organization.Routes())
+			r.Mount("/audit", h.Audit.Routes())
+			// Mount more protected handlers as needed
+		})
+	})
+
+	logger.Info("router configured")
+	return r
+}
+
+// NewAdminRouter serves operational endpoints that must not be exposed publicly, for the
+// listener on SERVER_ADMIN_HOST and SERVER_ADMIN_PORT. NewRouter never mounts them. Its
+// /readyz includes the errors of failed checks, which the public probe leaves out.
+func NewAdminRouter(m *metrics.Metrics, queryStats *store.QueryStats, checks *health.Registry) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Get("/readyz", checks.DetailedReadinessHandler())
+	r.Method(http.MethodGet, "/metrics", m.Handler())
+	r.Get("/debug/queries", queryStats.Handler())
+	return r
+}
+
+// Spec documents the routes NewRouter mounts under /api. Mount prefixes here must match
+// NewRouter; TestSpecMatchesRouter fails when they drift.
+func Spec(h *Handlers) *openapi.Document {
+	return openapi.NewBuilder(openapi.Info{
+		Title:   "go-web-template API",
+		Version: "1.0.0",
+	}).
+		Add("/api/auth", "auth", h.Auth.Operations()...).
+		Add("/api/invitations", "invitations", h.Invitation.Operations()...).
+		Add("/api/users", "users", h.User.Operations()...).
+		Add("/api/organizations", "organizations", h.Organization.Operations()...).
+		Add("/api/audit", "audit", h.Audit.Operations()...).
+		Document()
+}
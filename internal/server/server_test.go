@@ -0,0 +1,139 @@
+package server_test
+
+import (
+	"encoding/json"
+	"go-web-template/internal/config"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/health"
+	"go-web-template/internal/metrics"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/openapi"
+	"go-web-template/internal/server"
+	"go-web-template/internal/store"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// Routes that are deliberately left out of the spec
+var undocumented = map[string]bool{
+	"GET /health":            true,
+	"GET /livez":             true,
+	"GET /readyz":            true,
+	"GET /api/openapi.json":  true,
+	"GET /api/docs":          true,
+	"GET /api/docs/assets/*": true,
+}
+
+type ServerTestSuite struct {
+	suite.Suite
+	cfg *config.Config
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	suite.cfg = &config.Config{}
+	suite.cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	suite.cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	suite.cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+}
+
+// handlers wires real handlers around nil services; building routes never calls them.
+func (suite *ServerTestSuite) handlers(registrationEnabled bool) (*server.Handlers, *middleware.AuthMiddleware) {
+	logger := zap.NewNop()
+	authMiddleware := middleware.NewAuthMiddleware(suite.cfg, logger, time.Minute, time.Hour, time.Hour)
+	permissions := middleware.NewPermissionMiddleware(nil, nil, logger)
+	tenants := middleware.NewTenantMiddleware(nil, logger)
+
+	return &server.Handlers{
+		Auth:         auth.NewAuthHandler(nil, authMiddleware, nil, registrationEnabled),
+		User:         user.NewUserHandler(nil),
+		Invitation:   invitation.NewInvitationHandler(nil, nil, authMiddleware, permissions),
+		Organization: organization.NewOrganizationHandler(nil, nil, tenants, permissions),
+		Audit:        audit.NewAuditHandler(nil, permissions),
+		Health:       health.NewRegistry(time.Second, 0),
+		CORS:         middleware.NewCORS(nil),
+	}, authMiddleware
+}
+
+func (suite *ServerTestSuite) TestSpecMatchesRouter() {
+	for _, registrationEnabled := range []bool{true, false} {
+		h, authMiddleware := suite.handlers(registrationEnabled)
+		router := server.NewRouter(suite.cfg, h, authMiddleware, metrics.New(), zap.NewNop())
+
+		var routes []string
+		err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			route = method + " " + openapi.JoinPath(route, "")
+			if !undocumented[route] {
+				routes = append(routes, route)
+			}
+			return nil
+		})
+		suite.Require().NoError(err)
+		sort.Strings(routes)
+
+		suite.Assert().Equal(routes, server.Spec(h).Routes(),
+			"routes and OpenAPI operations drifted; update the handler's Operations")
+	}
+}
+
+func (suite *ServerTestSuite) TestServesSpec() {
+	h, authMiddleware := suite.handlers(true)
+	router := server.NewRouter(suite.cfg, h, authMiddleware, metrics.New(), zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	var doc openapi.Document
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &doc))
+	suite.Assert().Equal(openapi.Version, doc.OpenAPI)
+	suite.Assert().Contains(doc.Paths, "/api/auth/login")
+	suite.Assert().Contains(doc.Components.Schemas, "ProblemDetails")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/docs", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	suite.Assert().True(strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html"))
+	suite.Assert().NotContains(rec.Body.String(), "https://")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/docs/assets/VERSION", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	suite.Assert().Equal(http.StatusOK, rec.Code)
+}
+
+func (suite *ServerTestSuite) TestOperationalEndpointsOnlyOnAdminRouter() {
+	h, authMiddleware := suite.handlers(true)
+	m := metrics.New()
+	public := server.NewRouter(suite.cfg, h, authMiddleware, m, zap.NewNop())
+	admin := server.NewAdminRouter(m, store.NewQueryStats(), h.Health)
+
+	for _, path := range []string{"/metrics", "/debug/queries"} {
+		rec := httptest.NewRecorder()
+		public.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		suite.Assert().Equal(http.StatusNotFound, rec.Code, path)
+
+		rec = httptest.NewRecorder()
+		admin.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		suite.Assert().Equal(http.StatusOK, rec.Code, path)
+	}
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"errors"
+
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/store/pgerr"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var _ apperr.Classifier = ClassifyError
+
+// ClassifyError maps missing rows, constraint violations and serialization failures to
+// the apperr values handlers render, and returns nil for any other error. The API
+// registers it with apperr.RegisterClassifier so apperr needn't know about the driver.
+func ClassifyError(err error) *apperr.Error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return apperr.NotFound("resource not found").WithCause(err)
+	case pgerr.IsUniqueViolation(err):
+		return apperr.Conflict("resource already exists").WithCause(err)
+	case pgerr.IsForeignKeyViolation(err):
+		return apperr.Conflict("a referenced resource does not exist or is still in use").WithCause(err)
+	case pgerr.IsCheckViolation(err):
+		return apperr.BadRequest("a value violates a data constraint").WithCause(err)
+	case pgerr.IsSerializationFailure(err):
+		return apperr.Conflict("the request conflicted with a concurrent update, please retry").WithCode("concurrent_update").WithCause(err)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package store_test
+
+import (
+	"errors"
+	"fmt"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/store"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClassifyErrorTestSuite struct {
+	suite.Suite
+}
+
+func TestClassifyErrorTestSuite(t *testing.T) {
+	suite.Run(t, new(ClassifyErrorTestSuite))
+}
+
+func (suite *ClassifyErrorTestSuite) TestDriverErrors() {
+	cases := []struct {
+		err    error
+		status int
+		code   apperr.Code
+	}{
+		{pgx.ErrNoRows, http.StatusNotFound, apperr.CodeNotFound},
+		{&pgconn.PgError{Code: "23505"}, http.StatusConflict, apperr.CodeConflict},
+		{&pgconn.PgError{Code: "23503"}, http.StatusConflict, apperr.CodeConflict},
+		{&pgconn.PgError{Code: "23514"}, http.StatusBadRequest, apperr.CodeBadRequest},
+		{fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "40001"}), http.StatusConflict, "concurrent_update"},
+	}
+
+	for _, tc := range cases {
+		mapped := store.ClassifyError(tc.err)
+		suite.Require().NotNil(mapped, tc.err.Error())
+		suite.Equal(tc.status, mapped.Status, tc.err.Error())
+		suite.Equal(tc.code, mapped.Code, tc.err.Error())
+		suite.ErrorIs(mapped, tc.err)
+	}
+}
+
+func (suite *ClassifyErrorTestSuite) TestOtherErrorsAreLeftAlone() {
+	suite.Nil(store.ClassifyError(errors.New("connection reset")))
+}
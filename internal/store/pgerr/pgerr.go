@@ -0,0 +1,70 @@
+// Package pgerr classifies Postgres errors returned through pgx, so services can
+// translate constraint violations into domain errors without matching on strings.
+package pgerr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	NotNullViolation     = "23502"
+	ForeignKeyViolation  = "23503"
+	UniqueViolation      = "23505"
+	CheckViolation       = "23514"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+	InvalidCatalogName   = "3D000"
+)
+
+// Code returns the SQLSTATE of err, or "" when err is not a Postgres error.
+func Code(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+// Constraint returns the name of the violated constraint or index, if any.
+func Constraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
+
+// IsAuthorizationFailure reports a rejected login, e.g. a wrong password or a role that
+// doesn't exist.
+func IsAuthorizationFailure(err error) bool {
+	return strings.HasPrefix(Code(err), "28")
+}
+
+func IsUniqueViolation(err error) bool {
+	return Code(err) == UniqueViolation
+}
+
+func IsForeignKeyViolation(err error) bool {
+	return Code(err) == ForeignKeyViolation
+}
+
+func IsCheckViolation(err error) bool {
+	code := Code(err)
+	return code == CheckViolation || code == NotNullViolation
+}
+
+// IsSerializationFailure reports errors that abort a transaction only because of
+// concurrent activity, so retrying the whole transaction may succeed.
+func IsSerializationFailure(err error) bool {
+	code := Code(err)
+	return code == SerializationFailure || code == DeadlockDetected
+}
+
+// IsConstraint reports whether err violated the named constraint, e.g. "users_email_key".
+func IsConstraint(err error, name string) bool {
+	return Constraint(err) == name
+}
@@ -0,0 +1,47 @@
+package pgerr_test
+
+import (
+	"errors"
+	"fmt"
+	"go-web-template/internal/store/pgerr"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type PgErrTestSuite struct {
+	suite.Suite
+}
+
+func TestPgErrTestSuite(t *testing.T) {
+	suite.Run(t, new(PgErrTestSuite))
+}
+
+func (suite *PgErrTestSuite) TestClassifiesWrappedErrors() {
+	err := fmt.Errorf("create user: %w", &pgconn.PgError{Code: pgerr.UniqueViolation, ConstraintName: "users_email_key"})
+
+	suite.True(pgerr.IsUniqueViolation(err))
+	suite.True(pgerr.IsConstraint(err, "users_email_key"))
+	suite.False(pgerr.IsForeignKeyViolation(err))
+	suite.False(pgerr.IsSerializationFailure(err))
+}
+
+func (suite *PgErrTestSuite) TestSerializationFailures() {
+	suite.True(pgerr.IsSerializationFailure(&pgconn.PgError{Code: pgerr.SerializationFailure}))
+	suite.True(pgerr.IsSerializationFailure(&pgconn.PgError{Code: pgerr.DeadlockDetected}))
+}
+
+func (suite *PgErrTestSuite) TestNonPostgresErrors() {
+	err := errors.New("connection refused")
+
+	suite.Empty(pgerr.Code(err))
+	suite.Empty(pgerr.Constraint(err))
+	suite.False(pgerr.IsCheckViolation(err))
+}
+
+func (suite *PgErrTestSuite) TestAuthorizationFailures() {
+	suite.True(pgerr.IsAuthorizationFailure(&pgconn.PgError{Code: "28P01"}))
+	suite.False(pgerr.IsAuthorizationFailure(&pgconn.PgError{Code: pgerr.InvalidCatalogName}))
+	suite.False(pgerr.IsAuthorizationFailure(errors.New("connection refused")))
+}
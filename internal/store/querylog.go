@@ -0,0 +1,327 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/config"
+	"go-web-template/internal/database"
+	"go-web-template/internal/metrics"
+	"go-web-template/internal/tracing"
+	"go-web-template/internal/utils"
+	"go-web-template/pkg/logging"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+var _ DB = (*InstrumentedDB)(nil)
+
+// InstrumentedDB times every query run through it, including those in the transactions
+// it starts, by sqlc query name: into db_query_* metrics, into QueryStats, and as a
+// "slow query" warning when one takes longer than cfg.SlowQueryThreshold.
+type InstrumentedDB struct {
+	db            DB
+	metrics       *metrics.Metrics
+	stats         *QueryStats
+	slowThreshold time.Duration
+}
+
+// NewInstrumentedDB wraps db, a pool or a Router. m and stats may be nil.
+func NewInstrumentedDB(db DB, cfg config.DatabaseConfig, m *metrics.Metrics, stats *QueryStats) *InstrumentedDB {
+	return &InstrumentedDB{
+		db:            db,
+		metrics:       m,
+		stats:         stats,
+		slowThreshold: cfg.SlowQueryThreshold,
+	}
+}
+
+func (db *InstrumentedDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return db.exec(ctx, db.db, sql, args)
+}
+
+func (db *InstrumentedDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return db.query(ctx, db.db, sql, args)
+}
+
+func (db *InstrumentedDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return db.queryRow(ctx, db.db, sql, args)
+}
+
+func (db *InstrumentedDB) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	tx, err := db.db.BeginTx(ctx, txOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx, db: db}, nil
+}
+
+func (db *InstrumentedDB) exec(ctx context.Context, target database.DBTX, sql string, args []any) (pgconn.CommandTag, error) {
+	start := time.Now()
+	tag, err := target.Exec(ctx, sql, args...)
+	db.observe(ctx, sql, args, start, err)
+	return tag, err
+}
+
+// query times a query until its rows are closed, since pgx reads them as they are
+// iterated.
+func (db *InstrumentedDB) query(ctx context.Context, target database.DBTX, sql string, args []any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := target.Query(ctx, sql, args...)
+	if err != nil {
+		db.observe(ctx, sql, args, start, err)
+		return nil, err
+	}
+	return &instrumentedRows{Rows: rows, done: func(err error) {
+		db.observe(ctx, sql, args, start, err)
+	}}, nil
+}
+
+func (db *InstrumentedDB) queryRow(ctx context.Context, target database.DBTX, sql string, args []any) pgx.Row {
+	start := time.Now()
+	return &instrumentedRow{row: target.QueryRow(ctx, sql, args...), done: func(err error) {
+		db.observe(ctx, sql, args, start, err)
+	}}
+}
+
+func (db *InstrumentedDB) observe(ctx context.Context, sql string, args []any, start time.Time, err error) {
+	duration := time.Since(start)
+	name := tracing.QueryName(sql)
+	// A missing row is an answer, not a failed query
+	failed := err != nil && !errors.Is(err, pgx.ErrNoRows)
+
+	db.metrics.ObserveQuery(name, duration, failed)
+	db.stats.record(name, duration, failed)
+
+	if db.slowThreshold > 0 && duration >= db.slowThreshold {
+		logging.FromContext(ctx).Warn("slow query",
+			zap.String("query", name),
+			zap.Duration("duration", duration),
+			zap.Strings("args", redactArgs(args)),
+			zap.Error(err),
+		)
+	}
+}
+
+type instrumentedTx struct {
+	pgx.Tx
+	db *InstrumentedDB
+}
+
+func (tx *instrumentedTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	nested, err := tx.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: nested, db: tx.db}, nil
+}
+
+func (tx *instrumentedTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return tx.db.exec(ctx, tx.Tx, sql, args)
+}
+
+func (tx *instrumentedTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return tx.db.query(ctx, tx.Tx, sql, args)
+}
+
+func (tx *instrumentedTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return tx.db.queryRow(ctx, tx.Tx, sql, args)
+}
+
+type instrumentedRows struct {
+	pgx.Rows
+	done func(error)
+	once sync.Once
+}
+
+func (r *instrumentedRows) Close() {
+	r.Rows.Close()
+	r.once.Do(func() { r.done(r.Rows.Err()) })
+}
+
+type instrumentedRow struct {
+	row  pgx.Row
+	done func(error)
+}
+
+func (r *instrumentedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	r.done(err)
+	return err
+}
+
+// redactArgs describes query arguments for the log. Numbers, booleans and times are
+// kept, as they are mostly IDs, flags and timestamps that help reproduce the query;
+// strings and everything else may be personal data or secrets and only show their type.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = redactArg(arg)
+	}
+	return redacted
+}
+
+func redactArg(arg any) string {
+	switch v := arg.(type) {
+	case nil:
+		return "NULL"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+
+	if rv := reflect.ValueOf(arg); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return redactArg(rv.Elem().Interface())
+	}
+	return fmt.Sprintf("[redacted %T]", arg)
+}
+
+// QueryStats aggregates query timings by query name since the process started. It is
+// safe for concurrent use; a nil *QueryStats records nothing and reports no queries.
+type QueryStats struct {
+	mu      sync.Mutex
+	queries map[string]*queryStat
+}
+
+type queryStat struct {
+	calls  int64
+	errors int64
+	total  time.Duration
+	max    time.Duration
+}
+
+// QueryStat is the summary of one query served by QueryStats.Handler.
+type QueryStat struct {
+	Query   string  `json:"query"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	TotalMS float64 `json:"total_ms"`
+	MeanMS  float64 `json:"mean_ms"`
+	MaxMS   float64 `json:"max_ms"`
+}
+
+// Orders QueryStats.Top accepts
+const (
+	SortByMean  = "mean"
+	SortByTotal = "total"
+	SortByMax   = "max"
+	SortByCalls = "calls"
+)
+
+func NewQueryStats() *QueryStats {
+	return &QueryStats{queries: make(map[string]*queryStat)}
+}
+
+func (s *QueryStats) record(name string, duration time.Duration, failed bool) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat, ok := s.queries[name]
+	if !ok {
+		stat = &queryStat{}
+		s.queries[name] = stat
+	}
+	stat.calls++
+	if failed {
+		stat.errors++
+	}
+	stat.total += duration
+	stat.max = max(stat.max, duration)
+}
+
+// Top returns the n slowest queries ordered by sortBy, one of the SortBy constants,
+// slowest first.
+func (s *QueryStats) Top(n int, sortBy string) []QueryStat {
+	if s == nil {
+		return []QueryStat{}
+	}
+
+	s.mu.Lock()
+	stats := make([]QueryStat, 0, len(s.queries))
+	for name, stat := range s.queries {
+		stats = append(stats, QueryStat{
+			Query:   name,
+			Calls:   stat.calls,
+			Errors:  stat.errors,
+			TotalMS: milliseconds(stat.total),
+			MeanMS:  milliseconds(stat.total / time.Duration(stat.calls)),
+			MaxMS:   milliseconds(stat.max),
+		})
+	}
+	s.mu.Unlock()
+
+	key := func(stat QueryStat) float64 {
+		switch sortBy {
+		case SortByTotal:
+			return stat.TotalMS
+		case SortByMax:
+			return stat.MaxMS
+		case SortByCalls:
+			return float64(stat.Calls)
+		default:
+			return stat.MeanMS
+		}
+	}
+	slices.SortFunc(stats, func(a, b QueryStat) int {
+		if c := cmp.Compare(key(b), key(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Query, b.Query)
+	})
+
+	return stats[:min(n, len(stats))]
+}
+
+// Handler serves the slowest queries as JSON, e.g. /debug/queries?limit=5&sort=total.
+// limit defaults to 10 and sort to mean.
+func (s *QueryStats) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 10
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				utils.RespondProblem(w, r, apperr.BadRequest("limit must be a positive number"))
+				return
+			}
+			limit = n
+		}
+
+		sortBy := r.URL.Query().Get("sort")
+		switch sortBy {
+		case "":
+			sortBy = SortByMean
+		case SortByMean, SortByTotal, SortByMax, SortByCalls:
+		default:
+			utils.RespondProblem(w, r, apperr.BadRequest("sort must be one of mean, total, max or calls"))
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, map[string]any{
+			"sort":    sortBy,
+			"queries": s.Top(limit, sortBy),
+		})
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
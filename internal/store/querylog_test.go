@@ -0,0 +1,163 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-web-template/internal/config"
+	"go-web-template/internal/store"
+	"go-web-template/pkg/logging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeDB answers every query after delay with err.
+type fakeDB struct {
+	delay time.Duration
+	err   error
+}
+
+func (db *fakeDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	time.Sleep(db.delay)
+	return pgconn.NewCommandTag("UPDATE 1"), db.err
+}
+
+func (db *fakeDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	time.Sleep(db.delay)
+	return nil, db.err
+}
+
+func (db *fakeDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	time.Sleep(db.delay)
+	return fakeRow{err: db.err}
+}
+
+func (db *fakeDB) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(...any) error {
+	return r.err
+}
+
+type InstrumentedDBTestSuite struct {
+	suite.Suite
+	fake  *fakeDB
+	stats *store.QueryStats
+	logs  *observer.ObservedLogs
+	ctx   context.Context
+}
+
+func TestInstrumentedDBTestSuite(t *testing.T) {
+	suite.Run(t, new(InstrumentedDBTestSuite))
+}
+
+func (suite *InstrumentedDBTestSuite) SetupTest() {
+	suite.fake = &fakeDB{}
+	suite.stats = store.NewQueryStats()
+
+	core, logs := observer.New(zap.WarnLevel)
+	suite.logs = logs
+	suite.ctx = logging.WithContext(context.Background(), zap.New(core))
+}
+
+func (suite *InstrumentedDBTestSuite) db(threshold time.Duration) *store.InstrumentedDB {
+	return store.NewInstrumentedDB(suite.fake, config.DatabaseConfig{SlowQueryThreshold: threshold}, nil, suite.stats)
+}
+
+func (suite *InstrumentedDBTestSuite) TestLogsSlowQueriesWithRedactedArgs() {
+	suite.fake.delay = 20 * time.Millisecond
+	userID := int64(9)
+
+	_, err := suite.db(10*time.Millisecond).Exec(suite.ctx, "-- name: UpdateUser :exec\nUPDATE users SET email = $2 WHERE id = $1",
+		int64(7), "alice@example.com", nil, &userID, true, []byte("hash"))
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(1, suite.logs.Len())
+	entry := suite.logs.All()[0]
+	suite.Equal("slow query", entry.Message)
+	fields := entry.ContextMap()
+	suite.Equal("UpdateUser", fields["query"])
+	suite.Equal([]any{"7", "[redacted string]", "NULL", "9", "true", "[redacted []uint8]"}, fields["args"])
+}
+
+func (suite *InstrumentedDBTestSuite) TestFastQueriesAreNotLogged() {
+	db := suite.db(time.Hour)
+	_, err := db.Exec(suite.ctx, "-- name: DeleteSession :exec\nDELETE FROM sessions WHERE id = $1", int64(1))
+	suite.Require().NoError(err)
+
+	suite.Zero(suite.logs.Len())
+	suite.Equal(int64(1), suite.stats.Top(10, store.SortByCalls)[0].Calls)
+}
+
+func (suite *InstrumentedDBTestSuite) TestZeroThresholdDisablesLog() {
+	suite.fake.delay = time.Millisecond
+	_, err := suite.db(0).Exec(suite.ctx, "-- name: DeleteSession :exec\nDELETE FROM sessions WHERE id = $1", int64(1))
+	suite.Require().NoError(err)
+
+	suite.Zero(suite.logs.Len())
+}
+
+func (suite *InstrumentedDBTestSuite) TestStatsCountErrorsButNotMissingRows() {
+	db := suite.db(0)
+
+	suite.fake.err = pgx.ErrNoRows
+	var id int64
+	suite.ErrorIs(db.QueryRow(suite.ctx, "-- name: GetUserByID :one\nSELECT id FROM users WHERE id = $1", int64(1)).Scan(&id), pgx.ErrNoRows)
+
+	suite.fake.err = errors.New("connection reset")
+	_, err := db.Query(suite.ctx, "-- name: ListUsers :many\nSELECT id FROM users")
+	suite.Error(err)
+
+	stats := suite.stats.Top(10, store.SortByCalls)
+	suite.Require().Len(stats, 2)
+	byName := map[string]store.QueryStat{stats[0].Query: stats[0], stats[1].Query: stats[1]}
+	suite.Equal(int64(0), byName["GetUserByID"].Errors)
+	suite.Equal(int64(1), byName["ListUsers"].Errors)
+}
+
+func (suite *InstrumentedDBTestSuite) TestHandlerServesSlowestFirst() {
+	db := suite.db(0)
+	suite.fake.delay = 5 * time.Millisecond
+	_, _ = db.Exec(suite.ctx, "-- name: Slow :exec\nSELECT pg_sleep(1)")
+	suite.fake.delay = 0
+	for range 3 {
+		_, _ = db.Exec(suite.ctx, "-- name: Fast :exec\nSELECT 1")
+	}
+
+	var body struct {
+		Sort    string            `json:"sort"`
+		Queries []store.QueryStat `json:"queries"`
+	}
+	w := httptest.NewRecorder()
+	suite.stats.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/queries?limit=1", nil))
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+
+	suite.Equal(store.SortByMean, body.Sort)
+	suite.Require().Len(body.Queries, 1)
+	suite.Equal("Slow", body.Queries[0].Query)
+	suite.GreaterOrEqual(body.Queries[0].MaxMS, 5.0)
+
+	suite.Equal("Fast", suite.stats.Top(1, store.SortByCalls)[0].Query)
+}
+
+func (suite *InstrumentedDBTestSuite) TestHandlerRejectsBadParameters() {
+	for _, query := range []string{"?limit=0", "?limit=ten", "?sort=name"} {
+		w := httptest.NewRecorder()
+		suite.stats.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/queries"+query, nil))
+		suite.Equal(http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go-web-template/pkg/logging"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var _ DB = (*Router)(nil)
+
+// Router is the DB handed to sqlc and the TxManager when read replicas are configured.
+// Read-only queries go to a healthy replica, round-robin; writes, transactions and the
+// reads that follow a write in the same request go to the primary. A replica that can't
+// be reached is taken out of rotation and the query is run on the primary instead.
+//
+// Writes are recorded in the context's WriteMarker, from which middleware.ReadYourWrites
+// pins the client's next requests to the primary with WithPrimary.
+type Router struct {
+	primary  *pgxpool.Pool
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+type replica struct {
+	pool    *pgxpool.Pool
+	name    string
+	healthy atomic.Bool
+}
+
+func NewRouter(primary *pgxpool.Pool, replicas []*pgxpool.Pool) *Router {
+	r := &Router{primary: primary}
+	for _, pool := range replicas {
+		connConfig := pool.Config().ConnConfig
+		rep := &replica{pool: pool, name: net.JoinHostPort(connConfig.Host, strconv.Itoa(int(connConfig.Port)))}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+	return r
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a copy of ctx whose reads go to the primary, for code that must
+// see a write made outside the Router's knowledge.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// WriteMarker records whether a request wrote to the primary.
+type WriteMarker struct {
+	marked atomic.Bool
+}
+
+type writeMarkerKey struct{}
+
+// WithWriteMarker returns a copy of ctx whose writes are recorded in the returned
+// WriteMarker.
+func WithWriteMarker(ctx context.Context) (context.Context, *WriteMarker) {
+	m := &WriteMarker{}
+	return context.WithValue(ctx, writeMarkerKey{}, m), m
+}
+
+// Marked reports whether a write was recorded.
+func (m *WriteMarker) Marked() bool {
+	return m.marked.Load()
+}
+
+// MarkWrite records a write in ctx's WriteMarker, if it has one. The Router calls it for
+// its own writes; code that writes another way calls it so the client still reads its
+// changes from the primary.
+func MarkWrite(ctx context.Context) {
+	if m, ok := ctx.Value(writeMarkerKey{}).(*WriteMarker); ok {
+		m.marked.Store(true)
+	}
+}
+
+func (r *Router) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tag, err := r.primary.Exec(ctx, sql, args...)
+	if err == nil {
+		MarkWrite(ctx)
+	}
+	return tag, err
+}
+
+func (r *Router) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if rep := r.replicaFor(ctx, sql); rep != nil {
+		rows, err := rep.pool.Query(ctx, sql, args...)
+		if !isUnavailable(err) {
+			return rows, err
+		}
+		r.markUnhealthy(ctx, rep, err)
+	}
+
+	rows, err := r.primary.Query(ctx, sql, args...)
+	if err == nil && !IsReadOnlyQuery(sql) {
+		MarkWrite(ctx)
+	}
+	return rows, err
+}
+
+func (r *Router) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &routedRow{router: r, ctx: ctx, sql: sql, args: args}
+}
+
+// BeginTx starts a transaction on the primary. Committing a read-write transaction
+// counts as a write.
+func (r *Router) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	tx, err := r.primary.BeginTx(ctx, txOptions)
+	if err != nil || txOptions.AccessMode == pgx.ReadOnly {
+		return tx, err
+	}
+	return &routedTx{Tx: tx}, nil
+}
+
+// Watch pings the replicas every interval until ctx is done, taking replicas that fail
+// out of rotation and putting them back once they answer.
+func (r *Router) Watch(ctx context.Context, interval time.Duration) {
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx, interval)
+		}
+	}
+}
+
+func (r *Router) check(ctx context.Context, timeout time.Duration) {
+	for _, rep := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := rep.pool.Ping(pingCtx)
+		cancel()
+
+		switch {
+		case err != nil:
+			r.markUnhealthy(ctx, rep, err)
+		case !rep.healthy.Swap(true):
+			logging.FromContext(ctx).Info("database replica is back in rotation", zap.String("replica", rep.name))
+		}
+	}
+}
+
+func (r *Router) markUnhealthy(ctx context.Context, rep *replica, err error) {
+	if rep.healthy.Swap(false) {
+		logging.FromContext(ctx).Warn("database replica unavailable, reading from the primary",
+			zap.String("replica", rep.name),
+			zap.Error(err),
+		)
+	}
+}
+
+// replicaFor picks the replica for a query, or nil for the primary.
+func (r *Router) replicaFor(ctx context.Context, sql string) *replica {
+	if len(r.replicas) == 0 || ctx.Value(primaryKey{}) != nil || !IsReadOnlyQuery(sql) || wrote(ctx) {
+		return nil
+	}
+
+	start := r.next.Add(1)
+	for i := range uint64(len(r.replicas)) {
+		if rep := r.replicas[(start+i)%uint64(len(r.replicas))]; rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+// wrote reports whether the request already wrote, so its reads see the write.
+func wrote(ctx context.Context) bool {
+	m, ok := ctx.Value(writeMarkerKey{}).(*WriteMarker)
+	return ok && m.Marked()
+}
+
+// routedRow runs the query when scanned, so a replica that turns out to be unreachable
+// can be replaced by the primary.
+type routedRow struct {
+	router *Router
+	ctx    context.Context
+	sql    string
+	args   []any
+}
+
+func (row *routedRow) Scan(dest ...any) error {
+	r := row.router
+	if rep := r.replicaFor(row.ctx, row.sql); rep != nil {
+		err := rep.pool.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+		if !isUnavailable(err) {
+			return err
+		}
+		r.markUnhealthy(row.ctx, rep, err)
+	}
+
+	err := r.primary.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+	if err == nil && !IsReadOnlyQuery(row.sql) {
+		MarkWrite(row.ctx)
+	}
+	return err
+}
+
+type routedTx struct {
+	pgx.Tx
+}
+
+func (tx *routedTx) Commit(ctx context.Context) error {
+	err := tx.Tx.Commit(ctx)
+	if err == nil {
+		MarkWrite(ctx)
+	}
+	return err
+}
+
+// isUnavailable reports errors that mean the server couldn't run the query at all, as
+// opposed to the query failing.
+func isUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Connection exceptions, and shutdown or startup of the server
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "57P")
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) || pgconn.SafeToRetry(err)
+}
+
+var (
+	leadingComments = regexp.MustCompile(`^(\s*--[^\n]*\n)*\s*`)
+	selectKeyword   = regexp.MustCompile(`(?i)^SELECT\b`)
+	lockingClause   = regexp.MustCompile(`(?i)\bFOR\s+(NO\s+KEY\s+)?(UPDATE|SHARE|KEY\s+SHARE)\b`)
+)
+
+// IsReadOnlyQuery reports whether sql can run on a replica: a plain SELECT, after the
+// sqlc "-- name:" comment, without a locking clause. Anything else, including WITH,
+// which may contain a data-modifying statement, goes to the primary.
+func IsReadOnlyQuery(sql string) bool {
+	stmt := leadingComments.ReplaceAllString(sql, "")
+	return selectKeyword.MatchString(stmt) && !lockingClause.MatchString(stmt)
+}
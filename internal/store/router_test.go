@@ -0,0 +1,40 @@
+package store_test
+
+import (
+	"go-web-template/internal/store"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RouterTestSuite struct {
+	suite.Suite
+}
+
+func TestRouterTestSuite(t *testing.T) {
+	suite.Run(t, new(RouterTestSuite))
+}
+
+func (suite *RouterTestSuite) TestIsReadOnlyQuery() {
+	tests := []struct {
+		sql      string
+		readOnly bool
+	}{
+		{"-- name: GetUserByID :one\nSELECT id, email FROM users WHERE id = $1", true},
+		{"select count(*) from users", true},
+		{"  \n-- name: ListUsers :many\n-- with a second comment\nSELECT * FROM users", true},
+		{"-- name: GetUserForUpdate :one\nSELECT * FROM users WHERE id = $1 FOR UPDATE", false},
+		{"SELECT * FROM users WHERE id = $1 for no key update", false},
+		{"SELECT * FROM users WHERE id = $1 FOR SHARE", false},
+		{"SELECT * FROM users WHERE id = $1 FOR KEY SHARE SKIP LOCKED", false},
+		{"-- name: CreateUser :one\nINSERT INTO users (email) VALUES ($1) RETURNING *", false},
+		{"WITH deleted AS (DELETE FROM sessions RETURNING id) SELECT count(*) FROM deleted", false},
+		{"SELECTION", false},
+		{"", false},
+		{"SET LOCAL app.organization_id = '1'", false},
+	}
+
+	for _, tt := range tests {
+		suite.Equal(tt.readOnly, store.IsReadOnlyQuery(tt.sql), tt.sql)
+	}
+}
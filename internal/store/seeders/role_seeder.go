@@ -2,11 +2,11 @@ package seeders
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 
 	"go-web-template/internal/database"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -23,13 +23,17 @@ var defaultPermissions = []struct {
 	{"data:write", "Create and update data"},
 	{"data:delete", "Delete data"},
 	{"admin:access", "Access admin panel"},
+	{"invitations:manage", "Create, list and revoke invitations"},
+	{"audit:read", "Read the audit log"},
 }
 
 var defaultRoles = []struct {
 	name        string
 	isDefault   bool
 	description string
-	permissions []string
+	// organizationScoped roles can be given to organization members
+	organizationScoped bool
+	permissions        []string
 }{
 	{
 		name:        "super-admin",
@@ -40,26 +44,28 @@ var defaultRoles = []struct {
 		},
 	},
 	{
-		name:        "admin",
-		isDefault:   false,
-		description: "Administrator with full access",
+		name:               "admin",
+		isDefault:          false,
+		description:        "Administrator with full access",
+		organizationScoped: true,
 		permissions: []string{
 			"users:read", "users:write", "users:delete",
 			"data:read", "data:write", "data:delete",
-			"admin:access",
+			"admin:access", "invitations:manage", "audit:read",
 		},
 	},
 	{
-		name:        "user",
-		isDefault:   true,
-		description: "Regular user",
+		name:               "user",
+		isDefault:          true,
+		description:        "Regular user",
+		organizationScoped: true,
 		permissions: []string{
 			"data:read", "data:write",
 		},
 	},
 }
 
-func SeedRolesAndPermissions(ctx context.Context, q *database.Queries, logger *zap.Logger) error {
+func SeedRolesAndPermissions(ctx context.Context, q database.Querier, logger *zap.Logger) error {
 	logger.Info("seeding roles and permissions")
 
 	permissionIDs := make(map[string]int64)
@@ -71,10 +77,10 @@ func SeedRolesAndPermissions(ctx context.Context, q *database.Queries, logger *z
 			continue
 		}
 
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			created, err := q.CreatePermission(ctx, database.CreatePermissionParams{
 				Name:        p.name,
-				Description: sql.NullString{String: p.description, Valid: true},
+				Description: &p.description,
 			})
 			if err != nil {
 				return err
@@ -93,12 +99,12 @@ func SeedRolesAndPermissions(ctx context.Context, q *database.Queries, logger *z
 		if err == nil {
 			roleID = existing.ID
 			logger.Debug("role already exists", zap.String("name", r.name))
-		} else if errors.Is(err, sql.ErrNoRows) {
-			desc := sql.NullString{String: r.description, Valid: true}
+		} else if errors.Is(err, pgx.ErrNoRows) {
 			created, err := q.CreateRole(ctx, database.CreateRoleParams{
-				Name:        r.name,
-				IsDefault:   r.isDefault,
-				Description: desc,
+				Name:               r.name,
+				IsDefault:          r.isDefault,
+				Description:        &r.description,
+				OrganizationScoped: r.organizationScoped,
 			})
 			if err != nil {
 				return err
@@ -2,28 +2,36 @@ package seeders
 
 import (
 	"context"
-	"database/sql"
 	"go-web-template/internal/database"
 
 	"go-web-template/internal/config"
+	"go-web-template/internal/store"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-func SeedDatabase(ctx context.Context, db *sql.DB, logger *zap.Logger, cfg *config.Config, seedType string) error {
-	queries := database.New(db)
-
-	switch seedType {
-	case "core":
-		return seedCore(ctx, queries, logger, cfg)
-	case "full":
-		return seedFull(ctx, queries, logger, cfg)
-	default:
-		return nil
+// SeedDatabase runs the seeders in a single transaction, so a failed seed leaves no
+// half-created roles behind.
+func SeedDatabase(ctx context.Context, pool *pgxpool.Pool, logger *zap.Logger, cfg *config.Config, seedType string) error {
+	txm, err := store.NewTxManager(pool, cfg.Database)
+	if err != nil {
+		return err
 	}
+
+	return txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		switch seedType {
+		case "core":
+			return seedCore(ctx, q, logger, cfg)
+		case "full":
+			return seedFull(ctx, q, logger, cfg)
+		default:
+			return nil
+		}
+	})
 }
 
-func seedCore(ctx context.Context, q *database.Queries, logger *zap.Logger, cfg *config.Config) error {
+func seedCore(ctx context.Context, q database.Querier, logger *zap.Logger, cfg *config.Config) error {
 	logger.Info("seeding core data")
 
 	if err := SeedRolesAndPermissions(ctx, q, logger); err != nil {
@@ -41,7 +49,7 @@ func seedCore(ctx context.Context, q *database.Queries, logger *zap.Logger, cfg
 	return nil
 }
 
-func seedFull(ctx context.Context, q *database.Queries, logger *zap.Logger, cfg *config.Config) error {
+func seedFull(ctx context.Context, q database.Querier, logger *zap.Logger, cfg *config.Config) error {
 	logger.Info("seeding full data (dev mode)")
 
 	if err := seedCore(ctx, q, logger, cfg); err != nil {
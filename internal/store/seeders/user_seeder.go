@@ -2,26 +2,30 @@ package seeders
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"go-web-template/internal/config"
 	"go-web-template/internal/database"
+	"go-web-template/internal/validate"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
-func SeedRootUser(ctx context.Context, q *database.Queries, logger *zap.Logger, cfg *config.Config) error {
+func SeedRootUser(ctx context.Context, q database.Querier, logger *zap.Logger, cfg *config.Config) error {
 
 	logger.Info("seeding root user")
 
+	// Stored like any other address, so the root user can sign in with ROOT_USER as typed
+	email := validate.NormalizeEmail(cfg.Seed.RootUser)
+
 	// Check if root user already exists
-	_, err := q.GetUserByEmail(ctx, cfg.Seed.RootUser)
+	_, err := q.GetUserByEmail(ctx, email)
 	if err == nil {
 		logger.Info("root user already exists, skipping")
 		return nil
 	}
-	if !errors.Is(err, sql.ErrNoRows) {
+	if !errors.Is(err, pgx.ErrNoRows) {
 		return err
 	}
 
@@ -39,7 +43,7 @@ func SeedRootUser(ctx context.Context, q *database.Queries, logger *zap.Logger,
 	}
 
 	user, err := q.CreateUser(ctx, database.CreateUserParams{
-		Email:       cfg.Seed.RootUser,
+		Email:       email,
 		Password:    string(hashedPassword),
 		DisplayName: "Root Administrator",
 		RoleID:      adminRole.ID,
@@ -1,39 +1,144 @@
 package store
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 	"time"
 
 	"go-web-template/internal/config"
+	"go-web-template/internal/store/pgerr"
+	"go-web-template/internal/tracing"
 
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 )
 
-func NewDB(cfg config.DatabaseConfig) (*sql.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-	)
-
-	db, err := sql.Open("postgres", dsn)
+// migrationConns is the size of the pool migrations run on: goose's session lock and
+// the migration itself.
+const migrationConns = 2
+
+// Wait between connection attempts at startup, doubling from the first to the last
+const (
+	minConnectBackoff = 250 * time.Millisecond
+	maxConnectBackoff = 5 * time.Second
+)
+
+// NewPool opens the connection pool described by cfg, whose connections switch to
+// cfg.AppRole. While the database is not accepting connections yet, e.g. a container
+// that is still starting, it retries with backoff for up to cfg.ConnectRetryTimeout or
+// until ctx is done.
+func NewPool(ctx context.Context, cfg config.DatabaseConfig, logger *zap.Logger) (*pgxpool.Pool, error) {
+	return openPool(ctx, cfg, cfg.AppRole, logger)
+}
+
+// NewMigrationPool is NewPool for changing the schema: its connections stay DB_USER,
+// which owns the tables, instead of switching to cfg.AppRole.
+func NewMigrationPool(ctx context.Context, cfg config.DatabaseConfig, logger *zap.Logger) (*pgxpool.Pool, error) {
+	cfg.MaxOpenConns = migrationConns
+	cfg.MinConns = 0
+	return openPool(ctx, cfg, "", logger)
+}
+
+func openPool(ctx context.Context, cfg config.DatabaseConfig, role string, logger *zap.Logger) (*pgxpool.Pool, error) {
+	poolConfig, err := newPoolConfig(cfg, cfg.DSN(), role)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	if err := waitForDB(ctx, pool, cfg.ConnectRetryTimeout, logger); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// OpenReplicas opens a pool for each of cfg.ReplicaURLs without waiting for them: a
+// replica that is down is skipped by the Router until it answers.
+func OpenReplicas(ctx context.Context, cfg config.DatabaseConfig) ([]*pgxpool.Pool, error) {
+	replicas := make([]*pgxpool.Pool, 0, len(cfg.ReplicaURLs))
+	for i, dsn := range cfg.ReplicaDSNs() {
+		poolConfig, err := newPoolConfig(cfg, dsn, cfg.AppRole)
+		var replica *pgxpool.Pool
+		if err == nil {
+			replica, err = pgxpool.NewWithConfig(ctx, poolConfig)
+		}
+		if err != nil {
+			for _, opened := range replicas {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("failed to open replica %d: %w", i+1, err)
+		}
+		replicas = append(replicas, replica)
+	}
+	return replicas, nil
+}
+
+// newPoolConfig parses dsn and applies the pool settings of cfg. Every query is traced
+// when tracing is enabled, and connections switch to role unless it is empty.
+func newPoolConfig(cfg config.DatabaseConfig, dsn, role string) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+
+	// Zero values keep the pgxpool defaults
+	if cfg.MaxOpenConns > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+	}
+	poolConfig.MinConns = int32(cfg.MinConns)
+	if cfg.ConnMaxLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.ConnMaxLifetime
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.ConnMaxIdleTime
+	}
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer()
 
-	// Verify connection
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if role != "" {
+		setRole := "SET ROLE " + pgx.Identifier{role}.Sanitize()
+		poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			if _, err := conn.Exec(ctx, setRole); err != nil {
+				return fmt.Errorf("failed to switch to role %s: %w", role, err)
+			}
+			return nil
+		}
 	}
 
-	return db, nil
+	return poolConfig, nil
+}
+
+func waitForDB(ctx context.Context, pool *pgxpool.Pool, retryTimeout time.Duration, logger *zap.Logger) error {
+	deadline := time.Now().Add(retryTimeout)
+	backoff := minConnectBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := pool.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		// Wrong credentials or a missing database won't fix themselves
+		if pgerr.IsAuthorizationFailure(err) || pgerr.Code(err) == pgerr.InvalidCatalogName ||
+			time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("failed to ping database after %d attempt(s): %w", attempt, err)
+		}
+
+		logger.Warn("database not available yet, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to ping database: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 }
@@ -0,0 +1,76 @@
+package store_test
+
+import (
+	"context"
+	"go-web-template/internal/config"
+	"go-web-template/internal/store"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type NewPoolTestSuite struct {
+	suite.Suite
+	cfg config.DatabaseConfig
+}
+
+func TestNewPoolTestSuite(t *testing.T) {
+	suite.Run(t, new(NewPoolTestSuite))
+}
+
+// SetupTest points the configuration at a local port nothing listens on.
+func (suite *NewPoolTestSuite) SetupTest() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	suite.Require().NoError(listener.Close())
+
+	suite.cfg = config.DatabaseConfig{
+		Host:           "127.0.0.1",
+		Port:           strconv.Itoa(port),
+		User:           "postgres",
+		DBName:         "postgres",
+		SSLMode:        "disable",
+		ConnectTimeout: time.Second,
+		MaxOpenConns:   1,
+	}
+}
+
+func (suite *NewPoolTestSuite) TestRetriesUntilTimeout() {
+	suite.cfg.ConnectRetryTimeout = time.Second
+	core, logs := observer.New(zap.WarnLevel)
+
+	pool, err := store.NewPool(context.Background(), suite.cfg, zap.New(core))
+
+	suite.Nil(pool)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "failed to ping database after")
+	suite.GreaterOrEqual(logs.FilterMessage("database not available yet, retrying").Len(), 2)
+}
+
+func (suite *NewPoolTestSuite) TestNoRetryTimeoutTriesOnce() {
+	core, logs := observer.New(zap.WarnLevel)
+
+	_, err := store.NewPool(context.Background(), suite.cfg, zap.New(core))
+
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "after 1 attempt(s)")
+	suite.Zero(logs.Len())
+}
+
+func (suite *NewPoolTestSuite) TestStopsWhenContextIsDone() {
+	suite.cfg.ConnectRetryTimeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := store.NewPool(ctx, suite.cfg, zap.NewNop())
+
+	suite.Require().ErrorIs(err, context.DeadlineExceeded)
+	suite.Less(time.Since(start), 5*time.Second)
+}
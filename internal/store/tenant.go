@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrRowLevelSecurityBypassed is returned by CheckRowLevelSecurity when the connection's
+// role is not subject to the tenant policies.
+var ErrRowLevelSecurityBypassed = errors.New("database role bypasses row-level security")
+
+// SetTenant sets app.current_tenant for the remainder of the transaction, so row-level
+// security policies can enforce isolation even when a query forgets to filter by
+// organization. The setting is transaction-local and never leaks to the next request
+// on a pooled connection.
+func SetTenant(ctx context.Context, tx pgx.Tx, organizationID int64) error {
+	_, err := tx.Exec(ctx, `SELECT set_config('app.current_tenant', $1, true)`, strconv.FormatInt(organizationID, 10))
+	if err != nil {
+		return fmt.Errorf("failed to set tenant: %w", err)
+	}
+	return nil
+}
+
+// SetTenantBypass lets the remainder of the transaction see every tenant's rows. Like
+// SetTenant it only lasts until the transaction ends.
+func SetTenantBypass(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, `SELECT set_config('app.tenant_bypass', 'on', true)`)
+	if err != nil {
+		return fmt.Errorf("failed to bypass tenant: %w", err)
+	}
+	return nil
+}
+
+// CheckRowLevelSecurity fails when db's connections run as a role the tenant policies
+// don't apply to: a superuser, a role with BYPASSRLS, or the owner of the tenant tables
+// if FORCE ROW LEVEL SECURITY was dropped. The API refuses to start rather than serve
+// without tenant isolation.
+func CheckRowLevelSecurity(ctx context.Context, db DB) error {
+	var role string
+	var bypass bool
+	err := db.QueryRow(ctx, `
+		SELECT r.rolname, r.rolsuper OR r.rolbypassrls OR (
+			c.relowner = r.oid AND NOT c.relforcerowsecurity
+		)
+		FROM pg_roles r, pg_class c
+		WHERE r.rolname = current_user AND c.oid = 'organizations'::regclass`,
+	).Scan(&role, &bypass)
+	if err != nil {
+		return fmt.Errorf("failed to check row-level security: %w", err)
+	}
+	if bypass {
+		return fmt.Errorf("%w: %s; set DB_APP_ROLE or connect as a role without it", ErrRowLevelSecurityBypassed, role)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-web-template/internal/config"
+	"go-web-template/internal/database"
+	"go-web-template/internal/store/pgerr"
+	"go-web-template/internal/tenant"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TxOptions controls a single transaction started by TxManager.
+type TxOptions struct {
+	Isolation pgx.TxIsoLevel
+	ReadOnly  bool
+}
+
+// DB is where TxManager runs queries and starts transactions: a *pgxpool.Pool, or a
+// *Router to send reads outside transactions to replicas.
+type DB interface {
+	database.DBTX
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+type TxManagerInterface interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context, q database.Querier) error) error
+	WithTxOptions(ctx context.Context, opts TxOptions, fn func(ctx context.Context, q database.Querier) error) error
+}
+
+var _ TxManagerInterface = (*TxManager)(nil)
+
+// TxManager runs units of work in a transaction. Serialization failures and deadlocks
+// are retried, and when row-level security is enabled the tenant in ctx, or the bypass
+// from tenant.WithBypass, is applied to every transaction it starts.
+//
+// The callback's ctx carries the transaction: a WithTx call made with it joins the
+// outer transaction as a savepoint instead of opening a second, independent one. Only
+// the outermost call retries and commits.
+type TxManager struct {
+	db               DB
+	queries          *database.Queries
+	defaults         TxOptions
+	maxRetries       int
+	rowLevelSecurity bool
+}
+
+func NewTxManager(db DB, cfg config.DatabaseConfig) (*TxManager, error) {
+	isolation, err := ParseIsolationLevel(cfg.TxIsolation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxManager{
+		db:               db,
+		queries:          database.New(db),
+		defaults:         TxOptions{Isolation: isolation},
+		maxRetries:       cfg.TxMaxRetries,
+		rowLevelSecurity: cfg.RowLevelSecurity,
+	}, nil
+}
+
+// WithTx runs fn in a transaction with the manager's default options. fn is re-run from
+// scratch when the transaction is retried, so it must not have side effects outside q.
+func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context, q database.Querier) error) error {
+	return m.WithTxOptions(ctx, m.defaults, fn)
+}
+
+// WithTxOptions is WithTx with explicit options. When ctx already carries one of m's
+// transactions, fn joins it in a savepoint and opts are ignored: the isolation level and
+// access mode are fixed by the outermost call.
+func (m *TxManager) WithTxOptions(ctx context.Context, opts TxOptions, fn func(ctx context.Context, q database.Querier) error) error {
+	if t, ok := ctx.Value(txKey{}).(*Tx); ok && t.manager == m {
+		return t.join(ctx, fn)
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = m.run(ctx, opts, fn)
+		if err == nil || !pgerr.IsSerializationFailure(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt+1) * 10 * time.Millisecond):
+		}
+	}
+}
+
+func (m *TxManager) run(ctx context.Context, opts TxOptions, fn func(ctx context.Context, q database.Querier) error) error {
+	txOptions := pgx.TxOptions{IsoLevel: opts.Isolation}
+	if opts.ReadOnly {
+		txOptions.AccessMode = pgx.ReadOnly
+	}
+
+	tx, err := m.db.BeginTx(ctx, txOptions)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	t := &Tx{Queries: m.queries.WithTx(tx), tx: tx, manager: m, scope: scopeFrom(ctx)}
+	if m.rowLevelSecurity {
+		if err := t.scope.apply(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, t), t); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+type txKey struct{}
+
+// Tx is the Querier handed to WithTx callbacks.
+type Tx struct {
+	*database.Queries
+	tx         pgx.Tx
+	manager    *TxManager
+	scope      scope
+	savepoints int
+}
+
+// Savepoint runs fn inside a savepoint of the current transaction. If fn fails, only its
+// work is rolled back and the error is returned; the outer transaction stays usable.
+func (t *Tx) Savepoint(ctx context.Context, fn func(ctx context.Context, q database.Querier) error) error {
+	return t.savepoint(ctx, t.scope, fn)
+}
+
+// join runs a nested WithTx call in a savepoint. Under row-level security the savepoint
+// takes the tenant scope of the nested call's ctx, and the outer scope is restored once
+// it is released.
+func (t *Tx) join(ctx context.Context, fn func(ctx context.Context, q database.Querier) error) error {
+	if !t.manager.rowLevelSecurity {
+		return t.savepoint(ctx, t.scope, fn)
+	}
+	return t.savepoint(ctx, scopeFrom(ctx), fn)
+}
+
+func (t *Tx) savepoint(ctx context.Context, inner scope, fn func(ctx context.Context, q database.Querier) error) error {
+	t.savepoints++
+	name := fmt.Sprintf("sp_%d", t.savepoints)
+
+	if _, err := t.tx.Exec(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	outer := t.scope
+	err := t.runScoped(ctx, inner, fn)
+	t.scope = outer
+	if err != nil {
+		// Rolling back to the savepoint also undoes the inner scope's settings
+		if _, rbErr := t.tx.Exec(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to roll back savepoint: %w", rbErr))
+		}
+		return err
+	}
+
+	if _, err := t.tx.Exec(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return err
+	}
+	// Settings made inside a released savepoint persist, so put the outer scope back
+	if inner != outer {
+		return outer.replace(ctx, t.tx)
+	}
+	return nil
+}
+
+func (t *Tx) runScoped(ctx context.Context, inner scope, fn func(ctx context.Context, q database.Querier) error) error {
+	if inner != t.scope {
+		if err := inner.replace(ctx, t.tx); err != nil {
+			return err
+		}
+		t.scope = inner
+	}
+	return fn(context.WithValue(ctx, txKey{}, t), t)
+}
+
+// Savepoint runs fn in a savepoint when q belongs to a transaction and directly otherwise,
+// so code can nest units of work without knowing whether a caller already opened one.
+func Savepoint(ctx context.Context, q database.Querier, fn func(ctx context.Context, q database.Querier) error) error {
+	if tx, ok := q.(*Tx); ok {
+		return tx.Savepoint(ctx, fn)
+	}
+	return fn(ctx, q)
+}
+
+// scope is the tenant a transaction is restricted to under row-level security. An
+// explicit bypass wins over the request's tenant, e.g. creating an organization while
+// scoped to another.
+type scope struct {
+	bypass         bool
+	organizationID int64
+	hasTenant      bool
+}
+
+func scopeFrom(ctx context.Context) scope {
+	if tenant.Bypassed(ctx) {
+		return scope{bypass: true}
+	}
+	organizationID, ok := tenant.OrganizationID(ctx)
+	return scope{organizationID: organizationID, hasTenant: ok}
+}
+
+// apply restricts a fresh transaction, where neither setting has a value yet, to s.
+func (s scope) apply(ctx context.Context, tx pgx.Tx) error {
+	switch {
+	case s.bypass:
+		return SetTenantBypass(ctx, tx)
+	case s.hasTenant:
+		return SetTenant(ctx, tx, s.organizationID)
+	}
+	return nil
+}
+
+// replace switches a transaction that was running under another scope to s.
+func (s scope) replace(ctx context.Context, tx pgx.Tx) error {
+	bypass, organizationID := "off", ""
+	if s.bypass {
+		bypass = "on"
+	}
+	if s.hasTenant {
+		organizationID = strconv.FormatInt(s.organizationID, 10)
+	}
+
+	_, err := tx.Exec(ctx, `SELECT set_config('app.tenant_bypass', $1, true), set_config('app.current_tenant', $2, true)`, bypass, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant: %w", err)
+	}
+	return nil
+}
+
+// ParseIsolationLevel maps a Postgres isolation level name, e.g. "repeatable read", to its
+// pgx value. An empty string selects the server default.
+func ParseIsolationLevel(level string) (pgx.TxIsoLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "", "default":
+		return "", nil
+	case "read committed":
+		return pgx.ReadCommitted, nil
+	case "repeatable read":
+		return pgx.RepeatableRead, nil
+	case "serializable":
+		return pgx.Serializable, nil
+	default:
+		return "", fmt.Errorf("unsupported isolation level %q", level)
+	}
+}
@@ -0,0 +1,35 @@
+// Package tenant carries the active organization through request-scoped contexts.
+package tenant
+
+import "context"
+
+type ctxKey int
+
+const (
+	organizationIDKey ctxKey = iota
+	bypassKey
+)
+
+// WithOrganizationID returns a copy of ctx scoped to the given organization.
+func WithOrganizationID(ctx context.Context, organizationID int64) context.Context {
+	return context.WithValue(ctx, organizationIDKey, organizationID)
+}
+
+// OrganizationID returns the active organization, if the request was resolved to one.
+func OrganizationID(ctx context.Context) (int64, bool) {
+	organizationID, ok := ctx.Value(organizationIDKey).(int64)
+	return organizationID, ok
+}
+
+// WithBypass returns a copy of ctx for work that spans organizations, such as listing a
+// user's organizations or creating one. Row-level security hides every tenant's rows
+// from a transaction with neither a tenant nor the bypass, so this has to be explicit.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey, true)
+}
+
+// Bypassed reports whether ctx was marked by WithBypass.
+func Bypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey).(bool)
+	return bypass
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/exaring/otelpgx"
+)
+
+// QueryTracer instruments a pgx pool so every query becomes a child span of the
+// caller's span. Query text is recorded with its placeholders, never the bound
+// arguments.
+func QueryTracer() *otelpgx.Tracer {
+	return otelpgx.NewTracer(
+		otelpgx.WithSpanNameFunc(QuerySpanName),
+		// Waiting for a pooled connection shows as a gap in the parent span instead
+		otelpgx.WithDisableAcquireTracer(),
+	)
+}
+
+// QuerySpanName names query spans after the sqlc query, e.g. "db.GetUserByEmail" for
+// one starting with "-- name: GetUserByEmail :one", and after the SQL command for
+// anything else, e.g. "COMMIT".
+func QuerySpanName(query string) string {
+	if name := sqlcName(query); name != "" {
+		return "db." + name
+	}
+	return QueryName(query)
+}
+
+// QueryName identifies a query in logs and metrics: by its sqlc name, e.g.
+// "GetUserByEmail", or else by its SQL command, e.g. "COMMIT".
+func QueryName(query string) string {
+	if name := sqlcName(query); name != "" {
+		return name
+	}
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
+
+func sqlcName(query string) string {
+	if rest, ok := strings.CutPrefix(query, "-- name: "); ok {
+		if name, _, ok := strings.Cut(rest, " "); ok {
+			return name
+		}
+	}
+	return ""
+}
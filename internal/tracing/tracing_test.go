@@ -0,0 +1,74 @@
+package tracing_test
+
+import (
+	"go-web-template/internal/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	spans    *tracetest.SpanRecorder
+	provider trace.TracerProvider
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (suite *TracingTestSuite) SetupTest() {
+	suite.provider = otel.GetTracerProvider()
+	suite.spans = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.spans)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (suite *TracingTestSuite) TearDownTest() {
+	otel.SetTracerProvider(suite.provider)
+}
+
+func (suite *TracingTestSuite) TestMiddlewareContinuesTraceAndNamesRoute() {
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := tracing.Start(r.Context(), "items.Get")
+		span.End()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	ended := suite.spans.Ended()
+	suite.Require().Len(ended, 2)
+
+	service, server := ended[0], ended[1]
+	suite.Equal("items.Get", service.Name())
+	suite.Equal("GET /items/{id}", server.Name())
+	suite.Equal(trace.SpanKindServer, server.SpanKind())
+	suite.Contains(server.Attributes(), attribute.String("http.route", "/items/{id}"))
+
+	suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	suite.Equal("00f067aa0ba902b7", server.Parent().SpanID().String())
+	suite.Equal(server.SpanContext().SpanID(), service.Parent().SpanID())
+}
+
+func (suite *TracingTestSuite) TestQuerySpanName() {
+	suite.Equal("db.GetUserByEmail", tracing.QuerySpanName("-- name: GetUserByEmail :one\nSELECT 1"))
+	suite.Equal("SELECT", tracing.QuerySpanName("select set_config($1, $2, true)"))
+	suite.Equal("COMMIT", tracing.QuerySpanName("commit"))
+	suite.Equal("query", tracing.QuerySpanName(""))
+
+	suite.Equal("GetUserByEmail", tracing.QueryName("-- name: GetUserByEmail :one\nSELECT 1"))
+	suite.Equal("COMMIT", tracing.QueryName("commit"))
+}
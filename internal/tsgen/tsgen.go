@@ -0,0 +1,225 @@
+// Package tsgen renders Go API types as TypeScript interfaces, following the rules
+// encoding/json uses to marshal them.
+package tsgen
+
+import (
+	"bytes"
+	"fmt"
+	"go-web-template/internal/jsonfield"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const header = "// Code generated by cmd/gen-ts. DO NOT EDIT.\n"
+
+// File lists the types rendered into one TypeScript module. Types are zero values, e.g.
+// user.User{}. Named structs they reference are rendered alongside them unless another
+// File owns them, in which case they are imported.
+type File struct {
+	Path  string
+	Types []any
+}
+
+// Generate renders files and returns their contents keyed by path.
+func Generate(files []File) (map[string][]byte, error) {
+	owners := make(map[reflect.Type]string)
+	for _, f := range files {
+		for _, v := range f.Types {
+			t := reflect.TypeOf(v)
+			if t.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("tsgen: %s: %s is not a struct", f.Path, t)
+			}
+			if owner, dup := owners[t]; dup {
+				return nil, fmt.Errorf("tsgen: %s is listed in both %s and %s", t, owner, f.Path)
+			}
+			owners[t] = f.Path
+		}
+	}
+
+	out := make(map[string][]byte, len(files))
+	for _, f := range files {
+		g := &fileGen{path: f.Path, owners: owners, imports: make(map[string][]string), emitted: make(map[reflect.Type]bool), body: &bytes.Buffer{}}
+		for _, v := range f.Types {
+			g.queue = append(g.queue, reflect.TypeOf(v))
+		}
+		content, err := g.render()
+		if err != nil {
+			return nil, err
+		}
+		out[f.Path] = content
+	}
+
+	return out, nil
+}
+
+type fileGen struct {
+	path    string
+	owners  map[reflect.Type]string
+	imports map[string][]string
+	emitted map[reflect.Type]bool
+	names   map[string]reflect.Type
+	queue   []reflect.Type
+	body    *bytes.Buffer
+}
+
+func (g *fileGen) render() ([]byte, error) {
+	g.names = make(map[string]reflect.Type)
+	for len(g.queue) > 0 {
+		t := g.queue[0]
+		g.queue = g.queue[1:]
+		if g.emitted[t] {
+			continue
+		}
+		if other, taken := g.names[t.Name()]; taken {
+			return nil, fmt.Errorf("tsgen: %s: %s and %s both render as %s", g.path, other, t, t.Name())
+		}
+		g.emitted[t] = true
+		g.names[t.Name()] = t
+		g.writeInterface(t)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+
+	modules := make([]string, 0, len(g.imports))
+	for module := range g.imports {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		names := g.imports[module]
+		sort.Strings(names)
+		fmt.Fprintf(&buf, "import type { %s } from %q;\n", strings.Join(names, ", "), module)
+	}
+
+	buf.WriteString(g.body.String())
+	return buf.Bytes(), nil
+}
+
+func (g *fileGen) writeInterface(t reflect.Type) {
+	fmt.Fprintf(g.body, "\nexport interface %s {\n", t.Name())
+	g.writeFields(t)
+	g.body.WriteString("}\n")
+}
+
+func (g *fileGen) writeFields(t reflect.Type) {
+	for _, field := range jsonfield.Fields(t) {
+		optional := ""
+		if field.OmitEmpty {
+			optional = "?"
+		}
+		fmt.Fprintf(g.body, "    %s%s: %s;\n", propertyName(field.Name), optional, g.typeOf(field.Type))
+	}
+}
+
+func (g *fileGen) typeOf(t reflect.Type) string {
+	switch {
+	case t == jsonfield.TimeType:
+		// RFC 3339 string, not a Date
+		return "string"
+	case t == jsonfield.RawMessageType:
+		return "unknown"
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeOf(t.Elem()) + " | null"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Base64
+			return "string"
+		}
+		elemType := g.typeOf(jsonfield.Elem(t))
+		if strings.Contains(elemType, " ") {
+			elemType = "(" + elemType + ")"
+		}
+		return elemType + "[]"
+	case reflect.Map:
+		return "Record<string, " + g.typeOf(t.Elem()) + ">"
+	case reflect.Struct:
+		if t.Name() == "" {
+			outer := g.body
+			g.body = &bytes.Buffer{}
+			g.writeFields(t)
+			fields := g.body.String()
+			g.body = outer
+			return "{\n" + strings.ReplaceAll("    "+fields, "\n    ", "\n        ") + "    }"
+		}
+		return g.reference(t)
+	default:
+		return "unknown"
+	}
+}
+
+// reference names t, importing it when another file owns it and queueing it for this
+// file otherwise.
+func (g *fileGen) reference(t reflect.Type) string {
+	owner, owned := g.owners[t]
+	if owned && owner != g.path {
+		module := importPath(g.path, owner)
+		if !slices.Contains(g.imports[module], t.Name()) {
+			g.imports[module] = append(g.imports[module], t.Name())
+		}
+		return t.Name()
+	}
+
+	if !g.emitted[t] {
+		g.queue = append(g.queue, t)
+	}
+	return t.Name()
+}
+
+// importPath is the relative module specifier for target as seen from file. The client
+// imports with explicit .ts extensions, so it is kept.
+func importPath(file, target string) string {
+	rel := relPath(path.Dir(file), target)
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+func relPath(base, target string) string {
+	baseParts := splitPath(base)
+	targetParts := splitPath(target)
+
+	i := 0
+	for i < len(baseParts) && i < len(targetParts)-1 && baseParts[i] == targetParts[i] {
+		i++
+	}
+
+	parts := make([]string, 0, len(baseParts)-i+len(targetParts)-i)
+	for range baseParts[i:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, targetParts[i:]...)
+	return strings.Join(parts, "/")
+}
+
+func splitPath(p string) []string {
+	p = path.Clean(p)
+	if p == "." {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return fmt.Sprintf("%q", name)
+		}
+	}
+	return name
+}
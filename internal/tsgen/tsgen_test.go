@@ -0,0 +1,103 @@
+package tsgen_test
+
+import (
+	"encoding/json"
+	"go-web-template/internal/tsgen"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type Base struct {
+	ID int64 `json:"id"`
+}
+
+type Post struct {
+	Base
+	Title     string            `json:"title"`
+	Subtitle  *string           `json:"subtitle"`
+	Summary   *string           `json:"summary,omitempty"`
+	Views     int               `json:"views,omitempty"`
+	Published time.Time         `json:"published_at"`
+	Body      json.RawMessage   `json:"body"`
+	Tags      []*Tag            `json:"tags"`
+	Meta      map[string]string `json:"meta"`
+	Author    *Author           `json:"author,omitempty"`
+	Password  string            `json:"-"`
+	internal  string
+}
+
+type Author struct {
+	Name string `json:"name"`
+	Bio  struct {
+		Text string `json:"text"`
+	} `json:"bio"`
+}
+
+type TsgenTestSuite struct {
+	suite.Suite
+}
+
+func TestTsgenTestSuite(t *testing.T) {
+	suite.Run(t, new(TsgenTestSuite))
+}
+
+func (suite *TsgenTestSuite) TestRendersNestedTypes() {
+	out, err := tsgen.Generate([]tsgen.File{{Path: "src/posts/models.gen.ts", Types: []any{Post{}}}})
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(`// Code generated by cmd/gen-ts. DO NOT EDIT.
+
+export interface Post {
+    id: number;
+    title: string;
+    subtitle: string | null;
+    summary?: string;
+    views?: number;
+    published_at: string;
+    body: unknown;
+    tags: Tag[];
+    meta: Record<string, string>;
+    author?: Author;
+}
+
+export interface Tag {
+    name: string;
+}
+
+export interface Author {
+    name: string;
+    bio: {
+        text: string;
+    };
+}
+`, string(out["src/posts/models.gen.ts"]))
+}
+
+func (suite *TsgenTestSuite) TestImportsTypesOwnedByOtherFiles() {
+	out, err := tsgen.Generate([]tsgen.File{
+		{Path: "src/posts/models.gen.ts", Types: []any{Post{}}},
+		{Path: "src/tags/models.gen.ts", Types: []any{Tag{}}},
+		{Path: "src/authors/models.gen.ts", Types: []any{Author{}}},
+	})
+	suite.Require().NoError(err)
+
+	posts := string(out["src/posts/models.gen.ts"])
+	suite.Assert().Contains(posts, `import type { Author } from "../authors/models.gen.ts";`)
+	suite.Assert().Contains(posts, `import type { Tag } from "../tags/models.gen.ts";`)
+	suite.Assert().NotContains(posts, "export interface Tag")
+	suite.Assert().Contains(string(out["src/tags/models.gen.ts"]), "export interface Tag")
+}
+
+func (suite *TsgenTestSuite) TestRejectsDuplicateOwners() {
+	_, err := tsgen.Generate([]tsgen.File{
+		{Path: "a.gen.ts", Types: []any{Tag{}}},
+		{Path: "b.gen.ts", Types: []any{Tag{}}},
+	})
+	suite.Assert().Error(err)
+}
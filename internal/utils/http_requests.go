@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/validate"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// MaxRequestBodyBytes caps JSON request bodies.
+const MaxRequestBodyBytes = 1 << 20
+
+// DecodeAndValidate decodes a single JSON object into T, rejecting unknown fields and
+// oversized bodies, then runs T's Normalize and Validate methods if it has them. The
+// returned error is an *apperr.Error ready for RespondProblem.
+func DecodeAndValidate[T any](w http.ResponseWriter, r *http.Request) (T, error) {
+	var req T
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		return req, decodeError(err)
+	}
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return req, apperr.BadRequest("request body must contain a single JSON object")
+	}
+
+	if n, ok := any(&req).(validate.Normalizer); ok {
+		n.Normalize()
+	}
+	if v, ok := any(&req).(validate.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return req, err
+		}
+	}
+
+	return req, nil
+}
+
+func decodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return apperr.BadRequest("request body is empty")
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return apperr.BadRequest("request body contains malformed JSON").WithCause(err)
+	case errors.As(err, &typeErr):
+		return apperr.Validation(apperr.FieldError{
+			Field:   typeErr.Field,
+			Message: "must be " + jsonTypeName(typeErr.Type.Kind()),
+		})
+	case errors.As(err, &maxBytesErr):
+		return apperr.New(http.StatusRequestEntityTooLarge, "payload_too_large",
+			fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return apperr.Validation(apperr.FieldError{Field: field, Message: "is not allowed"})
+	default:
+		return apperr.BadRequest("invalid request body").WithCause(err)
+	}
+}
+
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		return "a valid value"
+	}
+}
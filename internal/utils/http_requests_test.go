@@ -0,0 +1,101 @@
+package utils_test
+
+import (
+	"errors"
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/utils"
+	"go-web-template/internal/validate"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type signupRequest struct {
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func (r *signupRequest) Normalize() {
+	r.Email = validate.NormalizeEmail(r.Email)
+}
+
+func (r *signupRequest) Validate() error {
+	var errs validate.Errors
+	errs.Email("email", r.Email)
+	if r.Age < 18 {
+		errs.Add("age", "must be at least 18")
+	}
+	return errs.Err()
+}
+
+type DecodeAndValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestDecodeAndValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(DecodeAndValidateTestSuite))
+}
+
+func (suite *DecodeAndValidateTestSuite) decode(body string) (signupRequest, *apperr.Error) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	result, err := utils.DecodeAndValidate[signupRequest](httptest.NewRecorder(), req)
+	if err == nil {
+		return result, nil
+	}
+
+	var appErr *apperr.Error
+	suite.Require().True(errors.As(err, &appErr), "expected *apperr.Error, got %T", err)
+	return result, appErr
+}
+
+func (suite *DecodeAndValidateTestSuite) TestNormalizesAndValidates() {
+	result, err := suite.decode(`{"email": "  Jane@Example.COM ", "age": 30}`)
+
+	suite.Nil(err)
+	suite.Equal("jane@example.com", result.Email)
+}
+
+func (suite *DecodeAndValidateTestSuite) TestAggregatesFieldErrors() {
+	_, err := suite.decode(`{"email": "", "age": 3}`)
+
+	suite.Require().NotNil(err)
+	suite.Equal(http.StatusUnprocessableEntity, err.Status)
+	suite.Equal([]apperr.FieldError{
+		{Field: "email", Message: "is required"},
+		{Field: "age", Message: "must be at least 18"},
+	}, err.Fields)
+}
+
+func (suite *DecodeAndValidateTestSuite) TestRejectsUnknownFields() {
+	_, err := suite.decode(`{"email": "jane@example.com", "age": 30, "admin": true}`)
+
+	suite.Require().NotNil(err)
+	suite.Equal([]apperr.FieldError{{Field: "admin", Message: "is not allowed"}}, err.Fields)
+}
+
+func (suite *DecodeAndValidateTestSuite) TestRejectsWrongTypes() {
+	_, err := suite.decode(`{"email": "jane@example.com", "age": "thirty"}`)
+
+	suite.Require().NotNil(err)
+	suite.Equal([]apperr.FieldError{{Field: "age", Message: "must be an integer"}}, err.Fields)
+}
+
+func (suite *DecodeAndValidateTestSuite) TestRejectsMalformedAndTrailingJSON() {
+	_, err := suite.decode(`{"email": `)
+	suite.Require().NotNil(err)
+	suite.Equal(http.StatusBadRequest, err.Status)
+
+	_, err = suite.decode(`{"email": "jane@example.com", "age": 30} {}`)
+	suite.Require().NotNil(err)
+	suite.Equal(http.StatusBadRequest, err.Status)
+}
+
+func (suite *DecodeAndValidateTestSuite) TestRejectsOversizedBody() {
+	_, err := suite.decode(`{"email": "` + strings.Repeat("a", utils.MaxRequestBodyBytes) + `"}`)
+
+	suite.Require().NotNil(err)
+	suite.Equal(http.StatusRequestEntityTooLarge, err.Status)
+}
@@ -2,12 +2,25 @@ package utils
 
 import (
 	"encoding/json"
+	"go-web-template/internal/apperr"
+	"go-web-template/pkg/logging"
 	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
 )
 
-type ErrorResponse struct {
-	Title   string `json:"title"`
-	Message string `json:"message"`
+// ProblemDetails is an RFC 9457 problem document extended with a stable code, the
+// request ID and field errors.
+type ProblemDetails struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	Code      apperr.Code         `json:"code"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []apperr.FieldError `json:"errors,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -23,10 +36,37 @@ func RespondJSON(w http.ResponseWriter, status int, data interface{}) {
 	}
 }
 
-func RespondError(w http.ResponseWriter, status int, message string) {
-	RespondJSON(w, status, ErrorResponse{
-		Title:   "Error",
-		Message: message,
+// RespondProblem renders err as application/problem+json. Only the public message of
+// the mapped apperr.Error reaches the client; the cause is logged with the request's logger.
+func RespondProblem(w http.ResponseWriter, r *http.Request, err error) {
+	appErr := apperr.From(err)
+	requestID := middleware.GetReqID(r.Context())
+
+	fields := []zap.Field{
+		zap.String("code", string(appErr.Code)),
+		zap.Int("status", appErr.Status),
+		zap.String("path", r.URL.Path),
+	}
+	if appErr.Err != nil {
+		fields = append(fields, zap.Error(appErr.Err))
+	}
+	if appErr.Status >= http.StatusInternalServerError {
+		logging.FromContext(r.Context()).Error("request failed", fields...)
+	} else {
+		logging.FromContext(r.Context()).Debug("request rejected", fields...)
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(appErr.Status)
+	_ = json.NewEncoder(w).Encode(ProblemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(appErr.Status),
+		Status:    appErr.Status,
+		Detail:    appErr.Message,
+		Instance:  r.URL.Path,
+		Code:      appErr.Code,
+		RequestID: requestID,
+		Errors:    appErr.Fields,
 	})
 }
 
@@ -0,0 +1,116 @@
+// Package validate collects field-level validation errors for request models.
+package validate
+
+import (
+	"go-web-template/internal/apperr"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is implemented by request models that check their own fields.
+type Validator interface {
+	Validate() error
+}
+
+// Normalizer is implemented by request models that canonicalize input, e.g. lowercase
+// emails, before validation.
+type Normalizer interface {
+	Normalize()
+}
+
+const MinPasswordLength = 8
+
+// MaxPasswordBytes is bcrypt's input limit; longer passwords can't be hashed.
+const MaxPasswordBytes = 72
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Errors accumulates field errors so a client sees every problem at once.
+type Errors struct {
+	fields []apperr.FieldError
+}
+
+func (e *Errors) Add(field, message string) {
+	e.fields = append(e.fields, apperr.FieldError{Field: field, Message: message})
+}
+
+func (e *Errors) Required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		e.Add(field, "is required")
+		return false
+	}
+	return true
+}
+
+func (e *Errors) MaxLength(field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		e.Add(field, "must be at most "+strconv.Itoa(max)+" characters")
+	}
+}
+
+// MaxBytes limits the encoded length of value, for fields such as passwords that are
+// bounded in bytes rather than characters.
+func (e *Errors) MaxBytes(field, value string, max int) {
+	if len(value) > max {
+		e.Add(field, "must be at most "+strconv.Itoa(max)+" bytes")
+	}
+}
+
+func (e *Errors) Email(field, value string) {
+	if !e.Required(field, value) {
+		return
+	}
+	if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+		e.Add(field, "must be a valid email address")
+		return
+	}
+	e.MaxLength(field, value, 255)
+}
+
+func (e *Errors) Password(field, value string) {
+	if !e.Required(field, value) {
+		return
+	}
+	if utf8.RuneCountInString(value) < MinPasswordLength {
+		e.Add(field, "must be at least "+strconv.Itoa(MinPasswordLength)+" characters")
+		return
+	}
+	e.MaxBytes(field, value, MaxPasswordBytes)
+}
+
+func (e *Errors) Matches(field, value, other, message string) {
+	if value != other {
+		e.Add(field, message)
+	}
+}
+
+func (e *Errors) Slug(field, value string) {
+	if !e.Required(field, value) {
+		return
+	}
+	if !slugPattern.MatchString(value) {
+		e.Add(field, "may only contain lowercase letters, digits and single dashes")
+	}
+}
+
+func (e *Errors) PositiveID(field string, value int64) {
+	if value < 1 {
+		e.Add(field, "is required")
+	}
+}
+
+// Err returns nil when no errors were added, otherwise an apperr validation error.
+func (e *Errors) Err() error {
+	if len(e.fields) == 0 {
+		return nil
+	}
+	return apperr.Validation(e.fields...)
+}
+
+// NormalizeEmail trims and lowercases an address so lookups are case-insensitive.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
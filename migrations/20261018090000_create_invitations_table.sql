@@ -0,0 +1,21 @@
+-- +goose Up
+-- Create invitations table
+CREATE TABLE invitations (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role_id BIGINT NOT NULL REFERENCES roles(id),
+    invited_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Only one open invitation per email
+CREATE UNIQUE INDEX idx_invitations_pending_email ON invitations(email)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+CREATE INDEX idx_invitations_role_id ON invitations(role_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- The active tenant is set per transaction with set_config('app.current_tenant', ...).
+-- When it is unset (non tenant-scoped work such as listing a user's organizations) the
+-- policies do not restrict rows; once it is set, rows of other tenants are invisible.
+-- +goose StatementBegin
+CREATE FUNCTION current_tenant_id() RETURNS BIGINT
+    LANGUAGE sql STABLE
+AS $$
+    SELECT NULLIF(current_setting('app.current_tenant', true), '')::BIGINT
+$$;
+-- +goose StatementEnd
+
+-- FORCE makes the policies apply to the table owner as well. Superusers and roles with
+-- BYPASSRLS are never subject to them, so the application must not connect as one.
+ALTER TABLE organizations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organizations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON organizations
+    USING (current_tenant_id() IS NULL OR id = current_tenant_id());
+
+ALTER TABLE organization_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organization_members FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON organization_members
+    USING (current_tenant_id() IS NULL OR organization_id = current_tenant_id());
+
+-- +goose Down
+DROP POLICY IF EXISTS tenant_isolation ON organization_members;
+ALTER TABLE organization_members NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE organization_members DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON organizations;
+ALTER TABLE organizations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE organizations DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS current_tenant_id();
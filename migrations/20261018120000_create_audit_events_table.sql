@@ -0,0 +1,39 @@
+-- +goose Up
+-- Append-only record of security-relevant actions. Actor and target are plain ids, not
+-- foreign keys, so events outlive the rows they describe.
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT,
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id BIGINT,
+    ip_address VARCHAR(45),
+    request_id VARCHAR(100),
+    changes JSONB NOT NULL DEFAULT '{}',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
+CREATE INDEX idx_audit_events_actor_id ON audit_events(actor_id);
+CREATE INDEX idx_audit_events_action ON audit_events(action);
+CREATE INDEX idx_audit_events_target ON audit_events(target_type, target_id);
+
+-- Events can be purged by retention, but never rewritten
+-- +goose StatementBegin
+CREATE FUNCTION audit_events_immutable() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_events_no_update
+    BEFORE UPDATE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_immutable();
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_immutable();
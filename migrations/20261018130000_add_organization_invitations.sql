@@ -0,0 +1,24 @@
+-- +goose Up
+-- Only organization-scoped roles can be given to members; global roles such as
+-- super-admin stay on users.role_id
+ALTER TABLE roles ADD COLUMN organization_scoped BOOLEAN NOT NULL DEFAULT false;
+UPDATE roles SET organization_scoped = true WHERE name IN ('admin', 'user');
+
+-- Invitations with an organization add a member to it rather than granting a global role
+ALTER TABLE invitations
+    ADD COLUMN organization_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE;
+
+-- One open invitation per email and organization
+DROP INDEX idx_invitations_pending_email;
+CREATE UNIQUE INDEX idx_invitations_pending_email ON invitations(email, COALESCE(organization_id, 0))
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+CREATE INDEX idx_invitations_organization_id ON invitations(organization_id);
+
+-- +goose Down
+DELETE FROM invitations WHERE organization_id IS NOT NULL;
+DROP INDEX idx_invitations_organization_id;
+DROP INDEX idx_invitations_pending_email;
+CREATE UNIQUE INDEX idx_invitations_pending_email ON invitations(email)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+ALTER TABLE invitations DROP COLUMN organization_id;
+ALTER TABLE roles DROP COLUMN organization_scoped;
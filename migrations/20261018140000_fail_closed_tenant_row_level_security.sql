@@ -0,0 +1,65 @@
+-- +goose Up
+-- Tenant policies fail closed: without app.current_tenant a transaction sees no tenant
+-- rows. Work that spans tenants, such as listing a user's organizations, sets
+-- app.tenant_bypass = 'on' for its transaction instead (store.TxManager does both).
+-- +goose StatementBegin
+CREATE FUNCTION tenant_bypass() RETURNS BOOLEAN
+    LANGUAGE sql STABLE
+AS $$
+    SELECT COALESCE(current_setting('app.tenant_bypass', true), '') = 'on'
+$$;
+-- +goose StatementEnd
+
+DROP POLICY tenant_isolation ON organizations;
+CREATE POLICY tenant_isolation ON organizations
+    USING (tenant_bypass() OR id = current_tenant_id());
+
+DROP POLICY tenant_isolation ON organization_members;
+CREATE POLICY tenant_isolation ON organization_members
+    USING (tenant_bypass() OR organization_id = current_tenant_id());
+
+-- Invitations to an organization belong to it; global invitations belong to no tenant
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invitations
+    USING (tenant_bypass() OR organization_id IS NULL OR organization_id = current_tenant_id());
+
+-- The API switches to app_user (DB_APP_ROLE) on every connection. It owns nothing and
+-- can't bypass RLS, so the policies hold even when DB_USER is the owner or a superuser.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'app_user') THEN
+        CREATE ROLE app_user NOLOGIN NOSUPERUSER NOBYPASSRLS;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+GRANT app_user TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO app_user;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO app_user;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO app_user;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO app_user;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO app_user;
+
+-- +goose Down
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM app_user;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM app_user;
+REVOKE USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public FROM app_user;
+REVOKE SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public FROM app_user;
+REVOKE USAGE ON SCHEMA public FROM app_user;
+-- The role is shared by every database of the cluster, so it is left in place
+
+DROP POLICY tenant_isolation ON invitations;
+ALTER TABLE invitations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE invitations DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY tenant_isolation ON organization_members;
+CREATE POLICY tenant_isolation ON organization_members
+    USING (current_tenant_id() IS NULL OR organization_id = current_tenant_id());
+
+DROP POLICY tenant_isolation ON organizations;
+CREATE POLICY tenant_isolation ON organizations
+    USING (current_tenant_id() IS NULL OR id = current_tenant_id());
+
+DROP FUNCTION tenant_bypass();
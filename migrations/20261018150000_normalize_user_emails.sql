@@ -0,0 +1,25 @@
+-- +goose Up
+-- Logins lowercase the address, so an account stored with capitals could never sign in.
+-- Accounts that only differ by case must be merged by hand before this can run.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    duplicate TEXT;
+BEGIN
+    SELECT lower(email) INTO duplicate FROM users GROUP BY lower(email) HAVING COUNT(*) > 1 LIMIT 1;
+    IF duplicate IS NOT NULL THEN
+        RAISE EXCEPTION 'several users have the email % in different case; merge them first', duplicate;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+UPDATE users SET email = lower(email) WHERE email <> lower(email);
+
+-- Case variants of an address can't be registered again
+DROP INDEX idx_users_email;
+CREATE UNIQUE INDEX idx_users_email_lower ON users(lower(email));
+
+-- +goose Down
+-- The original case of the addresses is not restored
+DROP INDEX idx_users_email_lower;
+CREATE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
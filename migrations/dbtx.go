@@ -0,0 +1,119 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"go-web-template/internal/database"
+)
+
+// dbtx runs the sqlc queries, written for pgx, on the database/sql transaction goose
+// hands to Go migrations. The pgx stdlib driver passes arguments through to pgx
+// unchanged, so they are encoded as they would be on a pool.
+type dbtx struct {
+	tx *sql.Tx
+}
+
+var _ database.DBTX = dbtx{}
+
+func (d dbtx) Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+	result, err := d.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	// database/sql only keeps the row count, which is all the queries read from the tag
+	return pgconn.NewCommandTag(strconv.FormatInt(n, 10)), nil
+}
+
+func (d dbtx) Query(ctx context.Context, query string, args ...any) (pgx.Rows, error) {
+	rows, err := d.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlRows{rows: rows}, nil
+}
+
+func (d dbtx) QueryRow(ctx context.Context, query string, args ...any) pgx.Row {
+	return sqlRow{row: d.tx.QueryRowContext(ctx, query, args...)}
+}
+
+// sqlRow reports a missing row as pgx.ErrNoRows, which callers of the queries check for.
+type sqlRow struct {
+	row *sql.Row
+}
+
+func (r sqlRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+// sqlRows implements the part of pgx.Rows that sqlc uses; the rest reports nothing.
+type sqlRows struct {
+	rows *sql.Rows
+	err  error
+}
+
+func (r *sqlRows) Close() {
+	if err := r.rows.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *sqlRows) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.rows.Err()
+}
+
+func (r *sqlRows) CommandTag() pgconn.CommandTag { return pgconn.CommandTag{} }
+
+func (r *sqlRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+
+func (r *sqlRows) Next() bool {
+	if r.rows.Next() {
+		return true
+	}
+	r.Close()
+	return false
+}
+
+func (r *sqlRows) Scan(dest ...any) error {
+	if err := r.rows.Scan(dest...); err != nil {
+		r.err = err
+		return err
+	}
+	return nil
+}
+
+func (r *sqlRows) Values() ([]any, error) {
+	columns, err := r.rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	return values, r.Scan(dest...)
+}
+
+func (r *sqlRows) RawValues() [][]byte { return nil }
+
+func (r *sqlRows) Conn() *pgx.Conn { return nil }
+
+func (r *sqlRows) TypeMap() *pgtype.Map { return nil }
@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-web-template/pkg/logging"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+
+	"go-web-template/internal/database"
+)
+
+// DefaultBatchSize is the batch size EachBatch uses when it is given none.
+const DefaultBatchSize = 1000
+
+// Func is the body of a Go migration. It runs in the transaction that records the
+// migration's version, so a failure leaves neither its changes nor the version behind.
+type Func func(ctx context.Context, tx *Tx) error
+
+// Tx is the transaction a Go migration runs in, with the sqlc queries bound to it.
+type Tx struct {
+	*sql.Tx
+	Queries *database.Queries
+	name    string
+}
+
+var goMigrations []*goose.Migration
+
+// Go returns the migrations written in Go, for the goose provider next to FS.
+func Go() []*goose.Migration {
+	return goMigrations
+}
+
+// register adds the Go migration of the calling file, which is named like the SQL
+// migrations: <version>_<name>.go. Call it from the file's init function.
+func register(up, down Func) {
+	_, file, _, _ := runtime.Caller(1)
+	goMigrations = append(goMigrations, NewGoMigration(filepath.Base(file), up, down))
+}
+
+// NewGoMigration builds the migration for source, a file name such as
+// 20261101120000_hash_tokens.go that carries its version. A nil down only removes the
+// version, for data changes that can't be undone. Tests use it to build fixtures; the
+// migrations of this package call register instead.
+func NewGoMigration(source string, up, down Func) *goose.Migration {
+	version, err := goose.NumericComponent(source)
+	if err != nil {
+		panic(fmt.Sprintf("migration %s is not named <version>_<name>.go", source))
+	}
+
+	name := strings.TrimSuffix(source, filepath.Ext(source))
+	m := goose.NewGoMigration(version, goFunc(name, up), goFunc(name, down))
+	m.Source = source
+	return m
+}
+
+func goFunc(name string, f Func) *goose.GoFunc {
+	if f == nil {
+		return &goose.GoFunc{Mode: goose.TransactionEnabled}
+	}
+	return &goose.GoFunc{RunTx: func(ctx context.Context, tx *sql.Tx) error {
+		return f(ctx, &Tx{Tx: tx, Queries: database.New(dbtx{tx}), name: name})
+	}}
+}
+
+// EachBatch calls fn with the ids of table in ascending order, size at a time, and logs
+// the progress after every batch. It pages by id rather than OFFSET, so the last batch
+// of a large table is as cheap as the first. All batches share the migration's
+// transaction: batching bounds memory and reports progress, it doesn't commit early.
+func (tx *Tx) EachBatch(ctx context.Context, table string, size int, fn func(ctx context.Context, ids []int64) error) error {
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	logger := logging.FromContext(ctx).With(zap.String("migration", tx.name), zap.String("table", table))
+	ident := pgx.Identifier{table}.Sanitize()
+
+	var total int64
+	if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM "+ident).Scan(&total); err != nil {
+		return fmt.Errorf("failed to count %s: %w", table, err)
+	}
+
+	start := time.Now()
+	var done, after int64
+	for {
+		ids, err := tx.batch(ctx, ident, after, size)
+		if err != nil {
+			return fmt.Errorf("failed to list %s after id %d: %w", table, after, err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		if err := fn(ctx, ids); err != nil {
+			return fmt.Errorf("batch of %s after id %d: %w", table, after, err)
+		}
+
+		done += int64(len(ids))
+		after = ids[len(ids)-1]
+		logger.Info("migration progress",
+			zap.Int64("done", done),
+			zap.Int64("total", total),
+			zap.Duration("elapsed", time.Since(start)),
+		)
+		if len(ids) < size {
+			break
+		}
+	}
+	return nil
+}
+
+func (tx *Tx) batch(ctx context.Context, ident string, after int64, size int) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM "+ident+" WHERE id > $1 ORDER BY id LIMIT $2", after, size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, size)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
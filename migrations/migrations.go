@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations and registers the ones written in Go, so
+// the binaries apply and check the schema they were built with wherever they run.
+package migrations
+
+import "embed"
+
+// FS holds the goose migrations, named <version>_<name>.sql, at its root.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,207 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	audit "go-web-template/internal/domains/audit"
+
+	database "go-web-template/internal/database"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockAuditServiceInterface is an autogenerated mock type for the AuditServiceInterface type
+type MockAuditServiceInterface struct {
+	mock.Mock
+}
+
+type MockAuditServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditServiceInterface) EXPECT() *MockAuditServiceInterface_Expecter {
+	return &MockAuditServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListEvents provides a mock function with given fields: ctx, filter, page, pageSize
+func (_m *MockAuditServiceInterface) ListEvents(ctx context.Context, filter audit.Filter, page int, pageSize int) (*audit.PaginatedEvents, error) {
+	ret := _m.Called(ctx, filter, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEvents")
+	}
+
+	var r0 *audit.PaginatedEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter, int, int) (*audit.PaginatedEvents, error)); ok {
+		return rf(ctx, filter, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter, int, int) *audit.PaginatedEvents); ok {
+		r0 = rf(ctx, filter, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*audit.PaginatedEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Filter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditServiceInterface_ListEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEvents'
+type MockAuditServiceInterface_ListEvents_Call struct {
+	*mock.Call
+}
+
+// ListEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter audit.Filter
+//   - page int
+//   - pageSize int
+func (_e *MockAuditServiceInterface_Expecter) ListEvents(ctx interface{}, filter interface{}, page interface{}, pageSize interface{}) *MockAuditServiceInterface_ListEvents_Call {
+	return &MockAuditServiceInterface_ListEvents_Call{Call: _e.mock.On("ListEvents", ctx, filter, page, pageSize)}
+}
+
+func (_c *MockAuditServiceInterface_ListEvents_Call) Run(run func(ctx context.Context, filter audit.Filter, page int, pageSize int)) *MockAuditServiceInterface_ListEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Filter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_ListEvents_Call) Return(_a0 *audit.PaginatedEvents, _a1 error) *MockAuditServiceInterface_ListEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_ListEvents_Call) RunAndReturn(run func(context.Context, audit.Filter, int, int) (*audit.PaginatedEvents, error)) *MockAuditServiceInterface_ListEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeBefore provides a mock function with given fields: ctx, before
+func (_m *MockAuditServiceInterface) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditServiceInterface_PurgeBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeBefore'
+type MockAuditServiceInterface_PurgeBefore_Call struct {
+	*mock.Call
+}
+
+// PurgeBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockAuditServiceInterface_Expecter) PurgeBefore(ctx interface{}, before interface{}) *MockAuditServiceInterface_PurgeBefore_Call {
+	return &MockAuditServiceInterface_PurgeBefore_Call{Call: _e.mock.On("PurgeBefore", ctx, before)}
+}
+
+func (_c *MockAuditServiceInterface_PurgeBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockAuditServiceInterface_PurgeBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_PurgeBefore_Call) Return(_a0 int64, _a1 error) *MockAuditServiceInterface_PurgeBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_PurgeBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockAuditServiceInterface_PurgeBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, q, evt
+func (_m *MockAuditServiceInterface) Record(ctx context.Context, q database.Querier, evt audit.Event) error {
+	ret := _m.Called(ctx, q, evt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.Querier, audit.Event) error); ok {
+		r0 = rf(ctx, q, evt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditServiceInterface_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditServiceInterface_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q database.Querier
+//   - evt audit.Event
+func (_e *MockAuditServiceInterface_Expecter) Record(ctx interface{}, q interface{}, evt interface{}) *MockAuditServiceInterface_Record_Call {
+	return &MockAuditServiceInterface_Record_Call{Call: _e.mock.On("Record", ctx, q, evt)}
+}
+
+func (_c *MockAuditServiceInterface_Record_Call) Run(run func(ctx context.Context, q database.Querier, evt audit.Event)) *MockAuditServiceInterface_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.Querier), args[2].(audit.Event))
+	})
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_Record_Call) Return(_a0 error) *MockAuditServiceInterface_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_Record_Call) RunAndReturn(run func(context.Context, database.Querier, audit.Event) error) *MockAuditServiceInterface_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditServiceInterface creates a new instance of MockAuditServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditServiceInterface {
+	mock := &MockAuditServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
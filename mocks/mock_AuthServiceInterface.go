@@ -0,0 +1,412 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	database "go-web-template/internal/database"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "go-web-template/internal/domains/user"
+)
+
+// MockAuthServiceInterface is an autogenerated mock type for the AuthServiceInterface type
+type MockAuthServiceInterface struct {
+	mock.Mock
+}
+
+type MockAuthServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuthServiceInterface) EXPECT() *MockAuthServiceInterface_Expecter {
+	return &MockAuthServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateUser provides a mock function with given fields: ctx, displayName, email, password
+func (_m *MockAuthServiceInterface) CreateUser(ctx context.Context, displayName string, email string, password string) (*user.User, error) {
+	ret := _m.Called(ctx, displayName, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*user.User, error)); ok {
+		return rf(ctx, displayName, email, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *user.User); ok {
+		r0 = rf(ctx, displayName, email, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, displayName, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthServiceInterface_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockAuthServiceInterface_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - displayName string
+//   - email string
+//   - password string
+func (_e *MockAuthServiceInterface_Expecter) CreateUser(ctx interface{}, displayName interface{}, email interface{}, password interface{}) *MockAuthServiceInterface_CreateUser_Call {
+	return &MockAuthServiceInterface_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, displayName, email, password)}
+}
+
+func (_c *MockAuthServiceInterface_CreateUser_Call) Run(run func(ctx context.Context, displayName string, email string, password string)) *MockAuthServiceInterface_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_CreateUser_Call) Return(_a0 *user.User, _a1 error) *MockAuthServiceInterface_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_CreateUser_Call) RunAndReturn(run func(context.Context, string, string, string) (*user.User, error)) *MockAuthServiceInterface_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUserInTx provides a mock function with given fields: ctx, q, displayName, email, password, roleID
+func (_m *MockAuthServiceInterface) CreateUserInTx(ctx context.Context, q database.Querier, displayName string, email string, password string, roleID int64) (*user.User, error) {
+	ret := _m.Called(ctx, q, displayName, email, password, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserInTx")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.Querier, string, string, string, int64) (*user.User, error)); ok {
+		return rf(ctx, q, displayName, email, password, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.Querier, string, string, string, int64) *user.User); ok {
+		r0 = rf(ctx, q, displayName, email, password, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.Querier, string, string, string, int64) error); ok {
+		r1 = rf(ctx, q, displayName, email, password, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthServiceInterface_CreateUserInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserInTx'
+type MockAuthServiceInterface_CreateUserInTx_Call struct {
+	*mock.Call
+}
+
+// CreateUserInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q database.Querier
+//   - displayName string
+//   - email string
+//   - password string
+//   - roleID int64
+func (_e *MockAuthServiceInterface_Expecter) CreateUserInTx(ctx interface{}, q interface{}, displayName interface{}, email interface{}, password interface{}, roleID interface{}) *MockAuthServiceInterface_CreateUserInTx_Call {
+	return &MockAuthServiceInterface_CreateUserInTx_Call{Call: _e.mock.On("CreateUserInTx", ctx, q, displayName, email, password, roleID)}
+}
+
+func (_c *MockAuthServiceInterface_CreateUserInTx_Call) Run(run func(ctx context.Context, q database.Querier, displayName string, email string, password string, roleID int64)) *MockAuthServiceInterface_CreateUserInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.Querier), args[2].(string), args[3].(string), args[4].(string), args[5].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_CreateUserInTx_Call) Return(_a0 *user.User, _a1 error) *MockAuthServiceInterface_CreateUserInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_CreateUserInTx_Call) RunAndReturn(run func(context.Context, database.Querier, string, string, string, int64) (*user.User, error)) *MockAuthServiceInterface_CreateUserInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUserWithRole provides a mock function with given fields: ctx, displayName, email, password, roleID
+func (_m *MockAuthServiceInterface) CreateUserWithRole(ctx context.Context, displayName string, email string, password string, roleID int64) (*user.User, error) {
+	ret := _m.Called(ctx, displayName, email, password, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserWithRole")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64) (*user.User, error)); ok {
+		return rf(ctx, displayName, email, password, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64) *user.User); ok {
+		r0 = rf(ctx, displayName, email, password, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int64) error); ok {
+		r1 = rf(ctx, displayName, email, password, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthServiceInterface_CreateUserWithRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserWithRole'
+type MockAuthServiceInterface_CreateUserWithRole_Call struct {
+	*mock.Call
+}
+
+// CreateUserWithRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - displayName string
+//   - email string
+//   - password string
+//   - roleID int64
+func (_e *MockAuthServiceInterface_Expecter) CreateUserWithRole(ctx interface{}, displayName interface{}, email interface{}, password interface{}, roleID interface{}) *MockAuthServiceInterface_CreateUserWithRole_Call {
+	return &MockAuthServiceInterface_CreateUserWithRole_Call{Call: _e.mock.On("CreateUserWithRole", ctx, displayName, email, password, roleID)}
+}
+
+func (_c *MockAuthServiceInterface_CreateUserWithRole_Call) Run(run func(ctx context.Context, displayName string, email string, password string, roleID int64)) *MockAuthServiceInterface_CreateUserWithRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_CreateUserWithRole_Call) Return(_a0 *user.User, _a1 error) *MockAuthServiceInterface_CreateUserWithRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_CreateUserWithRole_Call) RunAndReturn(run func(context.Context, string, string, string, int64) (*user.User, error)) *MockAuthServiceInterface_CreateUserWithRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProfile provides a mock function with given fields: ctx, userID
+func (_m *MockAuthServiceInterface) GetProfile(ctx context.Context, userID int64) (*user.User, []string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *user.User
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*user.User, []string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *user.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) []string); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAuthServiceInterface_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type MockAuthServiceInterface_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockAuthServiceInterface_Expecter) GetProfile(ctx interface{}, userID interface{}) *MockAuthServiceInterface_GetProfile_Call {
+	return &MockAuthServiceInterface_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, userID)}
+}
+
+func (_c *MockAuthServiceInterface_GetProfile_Call) Run(run func(ctx context.Context, userID int64)) *MockAuthServiceInterface_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_GetProfile_Call) Return(_a0 *user.User, _a1 []string, _a2 error) *MockAuthServiceInterface_GetProfile_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_GetProfile_Call) RunAndReturn(run func(context.Context, int64) (*user.User, []string, error)) *MockAuthServiceInterface_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: ctx, userID
+func (_m *MockAuthServiceInterface) GetUserByID(ctx context.Context, userID int64) (*user.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*user.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *user.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthServiceInterface_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockAuthServiceInterface_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockAuthServiceInterface_Expecter) GetUserByID(ctx interface{}, userID interface{}) *MockAuthServiceInterface_GetUserByID_Call {
+	return &MockAuthServiceInterface_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, userID)}
+}
+
+func (_c *MockAuthServiceInterface_GetUserByID_Call) Run(run func(ctx context.Context, userID int64)) *MockAuthServiceInterface_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_GetUserByID_Call) Return(_a0 *user.User, _a1 error) *MockAuthServiceInterface_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_GetUserByID_Call) RunAndReturn(run func(context.Context, int64) (*user.User, error)) *MockAuthServiceInterface_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateCredentials provides a mock function with given fields: ctx, email, password
+func (_m *MockAuthServiceInterface) ValidateCredentials(ctx context.Context, email string, password string) (*user.User, error) {
+	ret := _m.Called(ctx, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateCredentials")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*user.User, error)); ok {
+		return rf(ctx, email, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *user.User); ok {
+		r0 = rf(ctx, email, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthServiceInterface_ValidateCredentials_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateCredentials'
+type MockAuthServiceInterface_ValidateCredentials_Call struct {
+	*mock.Call
+}
+
+// ValidateCredentials is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+func (_e *MockAuthServiceInterface_Expecter) ValidateCredentials(ctx interface{}, email interface{}, password interface{}) *MockAuthServiceInterface_ValidateCredentials_Call {
+	return &MockAuthServiceInterface_ValidateCredentials_Call{Call: _e.mock.On("ValidateCredentials", ctx, email, password)}
+}
+
+func (_c *MockAuthServiceInterface_ValidateCredentials_Call) Run(run func(ctx context.Context, email string, password string)) *MockAuthServiceInterface_ValidateCredentials_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_ValidateCredentials_Call) Return(_a0 *user.User, _a1 error) *MockAuthServiceInterface_ValidateCredentials_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_ValidateCredentials_Call) RunAndReturn(run func(context.Context, string, string) (*user.User, error)) *MockAuthServiceInterface_ValidateCredentials_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuthServiceInterface creates a new instance of MockAuthServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuthServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuthServiceInterface {
+	mock := &MockAuthServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
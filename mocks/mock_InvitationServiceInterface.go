@@ -0,0 +1,509 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	invitation "go-web-template/internal/domains/invitation"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "go-web-template/internal/domains/user"
+)
+
+// MockInvitationServiceInterface is an autogenerated mock type for the InvitationServiceInterface type
+type MockInvitationServiceInterface struct {
+	mock.Mock
+}
+
+type MockInvitationServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInvitationServiceInterface) EXPECT() *MockInvitationServiceInterface_Expecter {
+	return &MockInvitationServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, token, displayName, password
+func (_m *MockInvitationServiceInterface) AcceptInvitation(ctx context.Context, token string, displayName string, password string) (*user.User, error) {
+	ret := _m.Called(ctx, token, displayName, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*user.User, error)); ok {
+		return rf(ctx, token, displayName, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *user.User); ok {
+		r0 = rf(ctx, token, displayName, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, displayName, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvitationServiceInterface_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type MockInvitationServiceInterface_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - displayName string
+//   - password string
+func (_e *MockInvitationServiceInterface_Expecter) AcceptInvitation(ctx interface{}, token interface{}, displayName interface{}, password interface{}) *MockInvitationServiceInterface_AcceptInvitation_Call {
+	return &MockInvitationServiceInterface_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, token, displayName, password)}
+}
+
+func (_c *MockInvitationServiceInterface_AcceptInvitation_Call) Run(run func(ctx context.Context, token string, displayName string, password string)) *MockInvitationServiceInterface_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_AcceptInvitation_Call) Return(_a0 *user.User, _a1 error) *MockInvitationServiceInterface_AcceptInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_AcceptInvitation_Call) RunAndReturn(run func(context.Context, string, string, string) (*user.User, error)) *MockInvitationServiceInterface_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, invitedBy, email, roleID
+func (_m *MockInvitationServiceInterface) CreateInvitation(ctx context.Context, invitedBy int64, email string, roleID int64) (*invitation.Invitation, string, error) {
+	ret := _m.Called(ctx, invitedBy, email, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 *invitation.Invitation
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) (*invitation.Invitation, string, error)); ok {
+		return rf(ctx, invitedBy, email, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) *invitation.Invitation); ok {
+		r0 = rf(ctx, invitedBy, email, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invitation.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int64) string); ok {
+		r1 = rf(ctx, invitedBy, email, roleID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, string, int64) error); ok {
+		r2 = rf(ctx, invitedBy, email, roleID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockInvitationServiceInterface_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type MockInvitationServiceInterface_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitedBy int64
+//   - email string
+//   - roleID int64
+func (_e *MockInvitationServiceInterface_Expecter) CreateInvitation(ctx interface{}, invitedBy interface{}, email interface{}, roleID interface{}) *MockInvitationServiceInterface_CreateInvitation_Call {
+	return &MockInvitationServiceInterface_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", ctx, invitedBy, email, roleID)}
+}
+
+func (_c *MockInvitationServiceInterface_CreateInvitation_Call) Run(run func(ctx context.Context, invitedBy int64, email string, roleID int64)) *MockInvitationServiceInterface_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_CreateInvitation_Call) Return(_a0 *invitation.Invitation, _a1 string, _a2 error) *MockInvitationServiceInterface_CreateInvitation_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_CreateInvitation_Call) RunAndReturn(run func(context.Context, int64, string, int64) (*invitation.Invitation, string, error)) *MockInvitationServiceInterface_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrganizationInvitation provides a mock function with given fields: ctx, invitedBy, organizationID, email, roleID
+func (_m *MockInvitationServiceInterface) CreateOrganizationInvitation(ctx context.Context, invitedBy int64, organizationID int64, email string, roleID int64) (*invitation.Invitation, string, error) {
+	ret := _m.Called(ctx, invitedBy, organizationID, email, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganizationInvitation")
+	}
+
+	var r0 *invitation.Invitation
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, int64) (*invitation.Invitation, string, error)); ok {
+		return rf(ctx, invitedBy, organizationID, email, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, int64) *invitation.Invitation); ok {
+		r0 = rf(ctx, invitedBy, organizationID, email, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invitation.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string, int64) string); ok {
+		r1 = rf(ctx, invitedBy, organizationID, email, roleID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64, string, int64) error); ok {
+		r2 = rf(ctx, invitedBy, organizationID, email, roleID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockInvitationServiceInterface_CreateOrganizationInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganizationInvitation'
+type MockInvitationServiceInterface_CreateOrganizationInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateOrganizationInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitedBy int64
+//   - organizationID int64
+//   - email string
+//   - roleID int64
+func (_e *MockInvitationServiceInterface_Expecter) CreateOrganizationInvitation(ctx interface{}, invitedBy interface{}, organizationID interface{}, email interface{}, roleID interface{}) *MockInvitationServiceInterface_CreateOrganizationInvitation_Call {
+	return &MockInvitationServiceInterface_CreateOrganizationInvitation_Call{Call: _e.mock.On("CreateOrganizationInvitation", ctx, invitedBy, organizationID, email, roleID)}
+}
+
+func (_c *MockInvitationServiceInterface_CreateOrganizationInvitation_Call) Run(run func(ctx context.Context, invitedBy int64, organizationID int64, email string, roleID int64)) *MockInvitationServiceInterface_CreateOrganizationInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_CreateOrganizationInvitation_Call) Return(_a0 *invitation.Invitation, _a1 string, _a2 error) *MockInvitationServiceInterface_CreateOrganizationInvitation_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_CreateOrganizationInvitation_Call) RunAndReturn(run func(context.Context, int64, int64, string, int64) (*invitation.Invitation, string, error)) *MockInvitationServiceInterface_CreateOrganizationInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JoinOrganization provides a mock function with given fields: ctx, token, userID
+func (_m *MockInvitationServiceInterface) JoinOrganization(ctx context.Context, token string, userID int64) (*invitation.Invitation, error) {
+	ret := _m.Called(ctx, token, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinOrganization")
+	}
+
+	var r0 *invitation.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*invitation.Invitation, error)); ok {
+		return rf(ctx, token, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *invitation.Invitation); ok {
+		r0 = rf(ctx, token, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invitation.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, token, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvitationServiceInterface_JoinOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinOrganization'
+type MockInvitationServiceInterface_JoinOrganization_Call struct {
+	*mock.Call
+}
+
+// JoinOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - userID int64
+func (_e *MockInvitationServiceInterface_Expecter) JoinOrganization(ctx interface{}, token interface{}, userID interface{}) *MockInvitationServiceInterface_JoinOrganization_Call {
+	return &MockInvitationServiceInterface_JoinOrganization_Call{Call: _e.mock.On("JoinOrganization", ctx, token, userID)}
+}
+
+func (_c *MockInvitationServiceInterface_JoinOrganization_Call) Run(run func(ctx context.Context, token string, userID int64)) *MockInvitationServiceInterface_JoinOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_JoinOrganization_Call) Return(_a0 *invitation.Invitation, _a1 error) *MockInvitationServiceInterface_JoinOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_JoinOrganization_Call) RunAndReturn(run func(context.Context, string, int64) (*invitation.Invitation, error)) *MockInvitationServiceInterface_JoinOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvitations provides a mock function with given fields: ctx
+func (_m *MockInvitationServiceInterface) ListInvitations(ctx context.Context) ([]*invitation.Invitation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 []*invitation.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*invitation.Invitation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*invitation.Invitation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*invitation.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvitationServiceInterface_ListInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvitations'
+type MockInvitationServiceInterface_ListInvitations_Call struct {
+	*mock.Call
+}
+
+// ListInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockInvitationServiceInterface_Expecter) ListInvitations(ctx interface{}) *MockInvitationServiceInterface_ListInvitations_Call {
+	return &MockInvitationServiceInterface_ListInvitations_Call{Call: _e.mock.On("ListInvitations", ctx)}
+}
+
+func (_c *MockInvitationServiceInterface_ListInvitations_Call) Run(run func(ctx context.Context)) *MockInvitationServiceInterface_ListInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_ListInvitations_Call) Return(_a0 []*invitation.Invitation, _a1 error) *MockInvitationServiceInterface_ListInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_ListInvitations_Call) RunAndReturn(run func(context.Context) ([]*invitation.Invitation, error)) *MockInvitationServiceInterface_ListInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizationInvitations provides a mock function with given fields: ctx, organizationID
+func (_m *MockInvitationServiceInterface) ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]*invitation.Invitation, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrganizationInvitations")
+	}
+
+	var r0 []*invitation.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*invitation.Invitation, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*invitation.Invitation); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*invitation.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvitationServiceInterface_ListOrganizationInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizationInvitations'
+type MockInvitationServiceInterface_ListOrganizationInvitations_Call struct {
+	*mock.Call
+}
+
+// ListOrganizationInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID int64
+func (_e *MockInvitationServiceInterface_Expecter) ListOrganizationInvitations(ctx interface{}, organizationID interface{}) *MockInvitationServiceInterface_ListOrganizationInvitations_Call {
+	return &MockInvitationServiceInterface_ListOrganizationInvitations_Call{Call: _e.mock.On("ListOrganizationInvitations", ctx, organizationID)}
+}
+
+func (_c *MockInvitationServiceInterface_ListOrganizationInvitations_Call) Run(run func(ctx context.Context, organizationID int64)) *MockInvitationServiceInterface_ListOrganizationInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_ListOrganizationInvitations_Call) Return(_a0 []*invitation.Invitation, _a1 error) *MockInvitationServiceInterface_ListOrganizationInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_ListOrganizationInvitations_Call) RunAndReturn(run func(context.Context, int64) ([]*invitation.Invitation, error)) *MockInvitationServiceInterface_ListOrganizationInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, id
+func (_m *MockInvitationServiceInterface) RevokeInvitation(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInvitationServiceInterface_RevokeInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeInvitation'
+type MockInvitationServiceInterface_RevokeInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockInvitationServiceInterface_Expecter) RevokeInvitation(ctx interface{}, id interface{}) *MockInvitationServiceInterface_RevokeInvitation_Call {
+	return &MockInvitationServiceInterface_RevokeInvitation_Call{Call: _e.mock.On("RevokeInvitation", ctx, id)}
+}
+
+func (_c *MockInvitationServiceInterface_RevokeInvitation_Call) Run(run func(ctx context.Context, id int64)) *MockInvitationServiceInterface_RevokeInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_RevokeInvitation_Call) Return(_a0 error) *MockInvitationServiceInterface_RevokeInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_RevokeInvitation_Call) RunAndReturn(run func(context.Context, int64) error) *MockInvitationServiceInterface_RevokeInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOrganizationInvitation provides a mock function with given fields: ctx, organizationID, id
+func (_m *MockInvitationServiceInterface) RevokeOrganizationInvitation(ctx context.Context, organizationID int64, id int64) error {
+	ret := _m.Called(ctx, organizationID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOrganizationInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInvitationServiceInterface_RevokeOrganizationInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOrganizationInvitation'
+type MockInvitationServiceInterface_RevokeOrganizationInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeOrganizationInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID int64
+//   - id int64
+func (_e *MockInvitationServiceInterface_Expecter) RevokeOrganizationInvitation(ctx interface{}, organizationID interface{}, id interface{}) *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call {
+	return &MockInvitationServiceInterface_RevokeOrganizationInvitation_Call{Call: _e.mock.On("RevokeOrganizationInvitation", ctx, organizationID, id)}
+}
+
+func (_c *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call) Run(run func(ctx context.Context, organizationID int64, id int64)) *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call) Return(_a0 error) *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockInvitationServiceInterface_RevokeOrganizationInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInvitationServiceInterface creates a new instance of MockInvitationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInvitationServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInvitationServiceInterface {
+	mock := &MockInvitationServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,7 +4,7 @@ package mocks
 
 import (
 	context "context"
-	models "go-web-template/internal/domains/user"
+	user "go-web-template/internal/domains/user"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,24 +22,82 @@ func (_m *MockUserServiceInterface) EXPECT() *MockUserServiceInterface_Expecter
 	return &MockUserServiceInterface_Expecter{mock: &_m.Mock}
 }
 
+// HasPermission provides a mock function with given fields: ctx, userID, permission
+func (_m *MockUserServiceInterface) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	ret := _m.Called(ctx, userID, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasPermission")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return rf(ctx, userID, permission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = rf(ctx, userID, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_HasPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasPermission'
+type MockUserServiceInterface_HasPermission_Call struct {
+	*mock.Call
+}
+
+// HasPermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - permission string
+func (_e *MockUserServiceInterface_Expecter) HasPermission(ctx interface{}, userID interface{}, permission interface{}) *MockUserServiceInterface_HasPermission_Call {
+	return &MockUserServiceInterface_HasPermission_Call{Call: _e.mock.On("HasPermission", ctx, userID, permission)}
+}
+
+func (_c *MockUserServiceInterface_HasPermission_Call) Run(run func(ctx context.Context, userID int64, permission string)) *MockUserServiceInterface_HasPermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_HasPermission_Call) Return(_a0 bool, _a1 error) *MockUserServiceInterface_HasPermission_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_HasPermission_Call) RunAndReturn(run func(context.Context, int64, string) (bool, error)) *MockUserServiceInterface_HasPermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListUsers provides a mock function with given fields: ctx, page, pageSize
-func (_m *MockUserServiceInterface) ListUsers(ctx context.Context, page int, pageSize int) (*models.PaginatedUsers, error) {
+func (_m *MockUserServiceInterface) ListUsers(ctx context.Context, page int, pageSize int) (*user.PaginatedUsers, error) {
 	ret := _m.Called(ctx, page, pageSize)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListUsers")
 	}
 
-	var r0 *models.PaginatedUsers
+	var r0 *user.PaginatedUsers
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.PaginatedUsers, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*user.PaginatedUsers, error)); ok {
 		return rf(ctx, page, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.PaginatedUsers); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *user.PaginatedUsers); ok {
 		r0 = rf(ctx, page, pageSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PaginatedUsers)
+			r0 = ret.Get(0).(*user.PaginatedUsers)
 		}
 	}
 
@@ -72,12 +130,12 @@ func (_c *MockUserServiceInterface_ListUsers_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockUserServiceInterface_ListUsers_Call) Return(_a0 *models.PaginatedUsers, _a1 error) *MockUserServiceInterface_ListUsers_Call {
+func (_c *MockUserServiceInterface_ListUsers_Call) Return(_a0 *user.PaginatedUsers, _a1 error) *MockUserServiceInterface_ListUsers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockUserServiceInterface_ListUsers_Call) RunAndReturn(run func(context.Context, int, int) (*models.PaginatedUsers, error)) *MockUserServiceInterface_ListUsers_Call {
+func (_c *MockUserServiceInterface_ListUsers_Call) RunAndReturn(run func(context.Context, int, int) (*user.PaginatedUsers, error)) *MockUserServiceInterface_ListUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,172 @@
+// Package client is a typed Go SDK for the API.
+//
+// Sessions use the same cookies as the web client. A Client keeps them in a cookie jar,
+// so the access token the server re-issues from the refresh cookie is picked up
+// transparently. A Client created WithCredentials also logs in again and retries once
+// when the session has expired entirely.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+
+	credentialsMu sync.Mutex
+	credentials   *LoginRequest
+
+	Auth          *AuthClient
+	Users         *UsersClient
+	Invitations   *InvitationsClient
+	Organizations *OrganizationsClient
+	Audit         *AuditClient
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client. A nil Jar is replaced with a fresh one,
+// since the API authenticates with cookies.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCredentials makes the client log in with the given account whenever a request is
+// rejected as unauthenticated, which includes the first one, and then retry it.
+func WithCredentials(email, password string) Option {
+	return func(c *Client) {
+		c.credentials = &LoginRequest{Email: email, Password: password}
+	}
+}
+
+// New returns a client for the server at baseURL, e.g. "https://example.com". Paths
+// are resolved against baseURL + "/api".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/") + "/api/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	c := &Client{baseURL: u, httpClient: &http.Client{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		httpClient := *c.httpClient
+		httpClient.Jar = jar
+		c.httpClient = &httpClient
+	}
+
+	c.Auth = &AuthClient{c: c}
+	c.Users = &UsersClient{c: c}
+	c.Invitations = &InvitationsClient{c: c}
+	c.Organizations = &OrganizationsClient{c: c}
+	c.Audit = &AuditClient{c: c}
+
+	return c, nil
+}
+
+// do sends a JSON request to path, relative to /api, and decodes a successful response
+// into out when it is non-nil. Error responses are returned as *Error.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	err := c.send(ctx, method, path, query, payload, out)
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized && path != loginPath {
+		if relogged, loginErr := c.relogin(ctx); loginErr != nil {
+			return errors.Join(err, loginErr)
+		} else if relogged {
+			return c.send(ctx, method, path, query, payload, out)
+		}
+	}
+
+	return err
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte, out any) error {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// relogin logs in with the configured credentials and reports whether it did.
+func (c *Client) relogin(ctx context.Context) (bool, error) {
+	c.credentialsMu.Lock()
+	defer c.credentialsMu.Unlock()
+
+	if c.credentials == nil {
+		return false, nil
+	}
+	if err := c.send(ctx, http.MethodPost, loginPath, nil, mustMarshal(c.credentials), nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func mustMarshal(v any) []byte {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return payload
+}
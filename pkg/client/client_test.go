@@ -0,0 +1,214 @@
+package client_test
+
+import (
+	"context"
+	"go-web-template/internal/config"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/health"
+	"go-web-template/internal/metrics"
+	"go-web-template/internal/middleware"
+	"go-web-template/internal/server"
+	"go-web-template/pkg/client"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-web-template/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+	srv          *httptest.Server
+	authService  *mocks.MockAuthServiceInterface
+	userService  *mocks.MockUserServiceInterface
+	auditService *mocks.MockAuditServiceInterface
+	jar          *cookiejar.Jar
+	client       *client.Client
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+// SetupTest serves the real router, with mocked services, over HTTP.
+func (suite *ClientTestSuite) SetupTest() {
+	cfg := &config.Config{}
+	cfg.Auth.AccessSecret = "test-access-secret-key-for-testing"
+	cfg.Auth.RefreshSecret = "test-refresh-secret-key-for-testing"
+	cfg.Auth.EncodeIDSecret = "12345678901234567890123456789012"
+
+	logger := zap.NewNop()
+	suite.authService = mocks.NewMockAuthServiceInterface(suite.T())
+	suite.userService = mocks.NewMockUserServiceInterface(suite.T())
+	suite.auditService = mocks.NewMockAuditServiceInterface(suite.T())
+
+	authMiddleware := middleware.NewAuthMiddleware(cfg, logger, time.Minute, time.Hour, time.Hour)
+	permissions := middleware.NewPermissionMiddleware(suite.userService, nil, logger)
+	tenants := middleware.NewTenantMiddleware(nil, logger)
+
+	h := &server.Handlers{
+		Auth:         auth.NewAuthHandler(suite.authService, authMiddleware, nil, true),
+		User:         user.NewUserHandler(suite.userService),
+		Invitation:   invitation.NewInvitationHandler(nil, suite.authService, authMiddleware, permissions),
+		Organization: organization.NewOrganizationHandler(nil, nil, tenants, permissions),
+		Audit:        audit.NewAuditHandler(suite.auditService, permissions),
+		Health:       health.NewRegistry(time.Second, 0),
+		CORS:         middleware.NewCORS(nil),
+	}
+	suite.srv = httptest.NewServer(server.NewRouter(cfg, h, authMiddleware, metrics.New(), logger))
+
+	suite.client = suite.newClient()
+}
+
+func (suite *ClientTestSuite) TearDownTest() {
+	suite.srv.Close()
+}
+
+func (suite *ClientTestSuite) newClient(opts ...client.Option) *client.Client {
+	var err error
+	suite.jar, err = cookiejar.New(nil)
+	suite.Require().NoError(err)
+
+	c, err := client.New(suite.srv.URL, append([]client.Option{client.WithHTTPClient(&http.Client{Jar: suite.jar})}, opts...)...)
+	suite.Require().NoError(err)
+	return c
+}
+
+func (suite *ClientTestSuite) expectLogin() {
+	suite.authService.EXPECT().
+		ValidateCredentials(mock.Anything, "jane@example.com", "secret123").
+		Return(&user.User{ID: 7, Email: "jane@example.com", DisplayName: "Jane"}, nil).
+		Once()
+	suite.expectProfile()
+}
+
+// expectProfile answers one MeResponse, as login and /me both render.
+func (suite *ClientTestSuite) expectProfile() {
+	suite.authService.EXPECT().
+		GetProfile(mock.Anything, int64(7)).
+		Return(&user.User{ID: 7, Email: "jane@example.com", DisplayName: "Jane", Role: &user.Role{Name: "user"}}, []string{"users:read"}, nil).
+		Once()
+}
+
+func (suite *ClientTestSuite) expireCookie(name string) {
+	u, _ := url.Parse(suite.srv.URL)
+	suite.jar.SetCookies(u, []*http.Cookie{{Name: name, Path: "/", MaxAge: -1}})
+}
+
+func (suite *ClientTestSuite) hasCookie(name string) bool {
+	u, _ := url.Parse(suite.srv.URL)
+	for _, c := range suite.jar.Cookies(u) {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (suite *ClientTestSuite) TestLoginMeLogout() {
+	ctx := context.Background()
+	suite.expectLogin()
+	suite.expectProfile()
+
+	me, err := suite.client.Auth.Login(ctx, client.LoginRequest{Email: "jane@example.com", Password: "secret123"})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(7), me.ID)
+
+	me, err = suite.client.Auth.Me(ctx)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("Jane", me.DisplayName)
+
+	suite.Require().NoError(suite.client.Auth.Logout(ctx))
+	suite.Assert().False(suite.hasCookie("access"))
+
+	_, err = suite.client.Auth.Me(ctx)
+	suite.Assert().True(client.IsStatus(err, http.StatusUnauthorized))
+}
+
+func (suite *ClientTestSuite) TestRefreshesExpiredAccessToken() {
+	ctx := context.Background()
+	suite.expectLogin()
+	suite.expectProfile()
+
+	_, err := suite.client.Auth.Login(ctx, client.LoginRequest{Email: "jane@example.com", Password: "secret123"})
+	suite.Require().NoError(err)
+
+	suite.expireCookie("access")
+
+	_, err = suite.client.Auth.Me(ctx)
+	suite.Require().NoError(err)
+	suite.Assert().True(suite.hasCookie("access"), "the re-issued access cookie is stored")
+}
+
+func (suite *ClientTestSuite) TestLogsInAgainWithCredentials() {
+	c := suite.newClient(client.WithCredentials("jane@example.com", "secret123"))
+	suite.expectLogin()
+	suite.userService.EXPECT().
+		ListUsers(mock.Anything, 2, 5).
+		Return(&user.PaginatedUsers{Page: 2, PageSize: 5, Data: []*user.User{{ID: 7}}}, nil).
+		Once()
+
+	// No session yet: the first attempt is rejected, the client logs in and retries
+	users, err := c.Users.List(context.Background(), 2, 5)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(2, users.Page)
+	suite.Assert().Len(users.Data, 1)
+}
+
+func (suite *ClientTestSuite) TestDecodesProblemDetails() {
+	suite.authService.EXPECT().
+		CreateUser(mock.Anything, "Jane", "taken@example.com", "secret123").
+		Return(nil, auth.ErrEmailTaken).
+		Once()
+
+	_, err := suite.client.Auth.Register(context.Background(), client.RegisterRequest{
+		DisplayName:          "Jane",
+		Email:                "taken@example.com",
+		Password:             "secret123",
+		PasswordConfirmation: "secret123",
+	})
+	suite.Require().Error(err)
+	suite.Assert().True(client.HasCode(err, "email_taken"))
+
+	var apiErr *client.Error
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Assert().Equal(http.StatusConflict, apiErr.Status)
+	suite.Assert().NotEmpty(apiErr.RequestID)
+	suite.Assert().Equal([]client.FieldError{{Field: "email", Message: "is already registered"}}, apiErr.Fields)
+}
+
+func (suite *ClientTestSuite) TestValidationErrors() {
+	_, err := suite.client.Auth.Login(context.Background(), client.LoginRequest{Email: "not-an-email"})
+
+	var apiErr *client.Error
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Assert().Equal(http.StatusUnprocessableEntity, apiErr.Status)
+	suite.Assert().Len(apiErr.Fields, 2)
+}
+
+func (suite *ClientTestSuite) TestForbidden() {
+	suite.expectLogin()
+	suite.userService.EXPECT().
+		HasPermission(mock.Anything, int64(7), "audit:read").
+		Return(false, nil).
+		Once()
+
+	ctx := context.Background()
+	_, err := suite.client.Auth.Login(ctx, client.LoginRequest{Email: "jane@example.com", Password: "secret123"})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.Audit.ListEvents(ctx, client.AuditFilter{Action: "auth.login"})
+	suite.Assert().True(client.IsStatus(err, http.StatusForbidden))
+	suite.Assert().True(client.HasCode(err, "missing_permission"))
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Error is an API error response, decoded from its application/problem+json body.
+type Error struct {
+	Status    int          `json:"status"`
+	Title     string       `json:"title"`
+	Detail    string       `json:"detail"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id"`
+	Fields    []FieldError `json:"errors"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Code)
+	}
+	if len(e.Fields) > 0 {
+		fields := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			fields[i] = f.Field + " " + f.Message
+		}
+		msg += ": " + strings.Join(fields, ", ")
+	}
+	return fmt.Sprintf("api: %d %s", e.Status, msg)
+}
+
+// HasCode reports whether err is an API error with the given code, e.g. "email_taken".
+func HasCode(err error, code string) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// IsStatus reports whether err is an API error with the given HTTP status.
+func IsStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{Status: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err == nil && len(body) > 0 {
+		// Bodies that are not problem documents, e.g. from a proxy, keep the status
+		_ = json.Unmarshal(body, apiErr)
+		apiErr.Status = resp.StatusCode
+	}
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const loginPath = "auth/login"
+
+type AuthClient struct {
+	c *Client
+}
+
+// Login starts a session. The session cookies are kept by the client.
+func (a *AuthClient) Login(ctx context.Context, req LoginRequest) (*MeResponse, error) {
+	var me MeResponse
+	if err := a.c.do(ctx, http.MethodPost, loginPath, nil, req, &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+// Register creates an account and starts a session for it. It fails with 404 when the
+// server has public registration disabled.
+func (a *AuthClient) Register(ctx context.Context, req RegisterRequest) (*MeResponse, error) {
+	var me MeResponse
+	if err := a.c.do(ctx, http.MethodPost, "auth/register", nil, req, &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+func (a *AuthClient) Me(ctx context.Context) (*MeResponse, error) {
+	var me MeResponse
+	if err := a.c.do(ctx, http.MethodGet, "auth/me", nil, nil, &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+func (a *AuthClient) Logout(ctx context.Context) error {
+	return a.c.do(ctx, http.MethodPost, "auth/logout", nil, nil, nil)
+}
+
+type UsersClient struct {
+	c *Client
+}
+
+// List returns a page of users. Zero page or pageSize selects the server default.
+func (u *UsersClient) List(ctx context.Context, page, pageSize int) (*PaginatedUsers, error) {
+	var users PaginatedUsers
+	if err := u.c.do(ctx, http.MethodGet, "users", pageQuery(page, pageSize), nil, &users); err != nil {
+		return nil, err
+	}
+	return &users, nil
+}
+
+type InvitationsClient struct {
+	c *Client
+}
+
+func (i *InvitationsClient) List(ctx context.Context) ([]*Invitation, error) {
+	var invitations []*Invitation
+	if err := i.c.do(ctx, http.MethodGet, "invitations", nil, nil, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (i *InvitationsClient) Create(ctx context.Context, req CreateInvitationRequest) (*CreateInvitationResponse, error) {
+	var created CreateInvitationResponse
+	if err := i.c.do(ctx, http.MethodPost, "invitations", nil, req, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (i *InvitationsClient) Revoke(ctx context.Context, id int64) error {
+	return i.c.do(ctx, http.MethodDelete, "invitations/"+strconv.FormatInt(id, 10), nil, nil, nil)
+}
+
+// Accept creates the invited account. Unlike Register it does not start a session.
+func (i *InvitationsClient) Accept(ctx context.Context, req AcceptInvitationRequest) (*MeResponse, error) {
+	var me MeResponse
+	if err := i.c.do(ctx, http.MethodPost, "invitations/accept", nil, req, &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+// Join accepts an organization invitation with the signed-in account.
+func (i *InvitationsClient) Join(ctx context.Context, req JoinOrganizationRequest) (*Invitation, error) {
+	var inv Invitation
+	if err := i.c.do(ctx, http.MethodPost, "invitations/join", nil, req, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+type OrganizationsClient struct {
+	c *Client
+}
+
+// List returns the organizations the current user belongs to.
+func (o *OrganizationsClient) List(ctx context.Context) ([]*Organization, error) {
+	var orgs []*Organization
+	if err := o.c.do(ctx, http.MethodGet, "organizations", nil, nil, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+func (o *OrganizationsClient) Create(ctx context.Context, req CreateOrganizationRequest) (*Organization, error) {
+	var org Organization
+	if err := o.c.do(ctx, http.MethodPost, "organizations", nil, req, &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (o *OrganizationsClient) Get(ctx context.Context, orgID int64) (*Organization, error) {
+	var org Organization
+	if err := o.c.do(ctx, http.MethodGet, orgPath(orgID), nil, nil, &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (o *OrganizationsClient) ListMembers(ctx context.Context, orgID int64) ([]*Member, error) {
+	var members []*Member
+	if err := o.c.do(ctx, http.MethodGet, orgPath(orgID)+"/members", nil, nil, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (o *OrganizationsClient) UpdateMemberRole(ctx context.Context, orgID, userID int64, req UpdateMemberRoleRequest) error {
+	path := orgPath(orgID) + "/members/" + strconv.FormatInt(userID, 10)
+	return o.c.do(ctx, http.MethodPut, path, nil, req, nil)
+}
+
+func (o *OrganizationsClient) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	path := orgPath(orgID) + "/members/" + strconv.FormatInt(userID, 10)
+	return o.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func (o *OrganizationsClient) ListInvitations(ctx context.Context, orgID int64) ([]*Invitation, error) {
+	var invitations []*Invitation
+	if err := o.c.do(ctx, http.MethodGet, orgPath(orgID)+"/invitations", nil, nil, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Invite invites a user to the organization; they join with InvitationsClient.Join, or
+// InvitationsClient.Accept when they have no account yet.
+func (o *OrganizationsClient) Invite(ctx context.Context, orgID int64, req CreateInvitationRequest) (*CreateInvitationResponse, error) {
+	var created CreateInvitationResponse
+	if err := o.c.do(ctx, http.MethodPost, orgPath(orgID)+"/invitations", nil, req, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (o *OrganizationsClient) RevokeInvitation(ctx context.Context, orgID, id int64) error {
+	path := orgPath(orgID) + "/invitations/" + strconv.FormatInt(id, 10)
+	return o.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func orgPath(orgID int64) string {
+	return "organizations/" + strconv.FormatInt(orgID, 10)
+}
+
+type AuditClient struct {
+	c *Client
+}
+
+// AuditFilter narrows ListEvents. Zero fields are not filtered on.
+type AuditFilter struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   int64
+	Since      time.Time
+	Until      time.Time
+	Page       int
+	PageSize   int
+}
+
+func (a *AuditClient) ListEvents(ctx context.Context, filter AuditFilter) (*PaginatedEvents, error) {
+	query := pageQuery(filter.Page, filter.PageSize)
+	if filter.ActorID != 0 {
+		query.Set("actor_id", strconv.FormatInt(filter.ActorID, 10))
+	}
+	if filter.Action != "" {
+		query.Set("action", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query.Set("target_type", filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		query.Set("target_id", strconv.FormatInt(filter.TargetID, 10))
+	}
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		query.Set("until", filter.Until.Format(time.RFC3339))
+	}
+
+	var events PaginatedEvents
+	if err := a.c.do(ctx, http.MethodGet, "audit", query, nil, &events); err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
+
+func pageQuery(page, pageSize int) url.Values {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	return query
+}
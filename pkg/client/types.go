@@ -0,0 +1,163 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The request and response types mirror the API's JSON. They are declared here rather
+// than aliased from the server so the SDK doesn't depend on internal packages;
+// TestWireTypesMatchServer fails when the two drift apart.
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type SuccessResponse struct {
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type LoginRequest struct {
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"remember_me"`
+}
+
+type RegisterRequest struct {
+	DisplayName          string `json:"display_name"`
+	Email                string `json:"email"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"password_confirmation"`
+}
+
+// MeResponse is the signed-in user, as returned by AuthClient.Me.
+type MeResponse struct {
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	DisplayName    string     `json:"display_name"`
+	EmailConfirmed *time.Time `json:"email_confirmed,omitempty"`
+	Role           string     `json:"role"`
+	Permissions    []string   `json:"permissions"`
+}
+
+type User struct {
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	DisplayName    string     `json:"display_name"`
+	EmailConfirmed *time.Time `json:"email_confirmed,omitempty"`
+	RoleID         int64      `json:"role_id"`
+	Role           *Role      `json:"role,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type Role struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	IsDefault   bool      `json:"is_default"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type Permission struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PaginatedUsers struct {
+	Data       []*User `json:"data"`
+	Page       int     `json:"page"`
+	PageSize   int     `json:"page_size"`
+	Total      int     `json:"total"`
+	TotalPages int     `json:"total_pages"`
+}
+
+// Invitation is a pending or past invitation; OrganizationID is set for organization
+// invitations. Status is pending, accepted, revoked or expired.
+type Invitation struct {
+	ID             int64      `json:"id"`
+	Email          string     `json:"email"`
+	RoleID         int64      `json:"role_id"`
+	OrganizationID *int64     `json:"organization_id,omitempty"`
+	InvitedBy      *int64     `json:"invited_by,omitempty"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type CreateInvitationRequest struct {
+	Email  string `json:"email"`
+	RoleID int64  `json:"role_id"`
+}
+
+type CreateInvitationResponse struct {
+	Invitation *Invitation `json:"invitation"`
+	Token      string      `json:"token"`
+}
+
+type AcceptInvitationRequest struct {
+	Token                string `json:"token"`
+	DisplayName          string `json:"display_name"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"password_confirmation"`
+}
+
+// JoinOrganizationRequest is the body of InvitationsClient.Join.
+type JoinOrganizationRequest struct {
+	Token string `json:"token"`
+}
+
+type Organization struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	RoleID    *int64    `json:"role_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Member struct {
+	UserID      int64     `json:"user_id"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name"`
+	RoleID      int64     `json:"role_id"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type UpdateMemberRoleRequest struct {
+	RoleID int64 `json:"role_id"`
+}
+
+type AuditEvent struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type,omitempty"`
+	TargetID   *int64          `json:"target_id,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Changes    json.RawMessage `json:"changes"`
+	Metadata   json.RawMessage `json:"metadata"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type PaginatedEvents struct {
+	Data       []*AuditEvent `json:"data"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	Total      int           `json:"total"`
+	TotalPages int           `json:"total_pages"`
+}
@@ -0,0 +1,92 @@
+package client_test
+
+import (
+	"go-web-template/internal/apperr"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/domains/user"
+	"go-web-template/internal/utils"
+	"go-web-template/pkg/client"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WireTypesTestSuite struct {
+	suite.Suite
+}
+
+func TestWireTypesTestSuite(t *testing.T) {
+	suite.Run(t, new(WireTypesTestSuite))
+}
+
+// TestWireTypesMatchServer compares the JSON shape of each SDK type with the server type
+// it mirrors: field names, omitempty and the shape of every value, recursively.
+func (suite *WireTypesTestSuite) TestWireTypesMatchServer() {
+	pairs := []struct {
+		client, server any
+	}{
+		{client.FieldError{}, apperr.FieldError{}},
+		{client.SuccessResponse{}, utils.SuccessResponse{}},
+		{client.LoginRequest{}, auth.LoginRequest{}},
+		{client.RegisterRequest{}, auth.RegisterRequest{}},
+		{client.MeResponse{}, auth.MeResponse{}},
+		{client.User{}, user.User{}},
+		{client.Role{}, user.Role{}},
+		{client.Permission{}, user.Permission{}},
+		{client.PaginatedUsers{}, user.PaginatedUsers{}},
+		{client.Invitation{}, invitation.Invitation{}},
+		{client.CreateInvitationRequest{}, invitation.CreateInvitationRequest{}},
+		{client.CreateInvitationResponse{}, invitation.CreateInvitationResponse{}},
+		{client.AcceptInvitationRequest{}, invitation.AcceptInvitationRequest{}},
+		{client.JoinOrganizationRequest{}, invitation.JoinOrganizationRequest{}},
+		{client.Organization{}, organization.Organization{}},
+		{client.Member{}, organization.Member{}},
+		{client.CreateOrganizationRequest{}, organization.CreateOrganizationRequest{}},
+		{client.UpdateMemberRoleRequest{}, organization.UpdateMemberRoleRequest{}},
+		{client.AuditEvent{}, audit.AuditEvent{}},
+		{client.PaginatedEvents{}, audit.PaginatedEvents{}},
+	}
+
+	for _, p := range pairs {
+		clientType, serverType := reflect.TypeOf(p.client), reflect.TypeOf(p.server)
+		suite.Equal(serverType.Name(), clientType.Name())
+		suite.Equal(wireShape(serverType), wireShape(clientType), "client.%s drifted from the API", clientType.Name())
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// wireShape describes how t encodes to JSON, ignoring Go names and packages.
+func wireShape(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "time"
+	case t.Kind() == reflect.Pointer:
+		return "*" + wireShape(t.Elem())
+	case t.Kind() == reflect.Slice:
+		return "[]" + wireShape(t.Elem())
+	case t.Kind() == reflect.Map:
+		return "map[" + wireShape(t.Key()) + "]" + wireShape(t.Elem())
+	case t.Kind() != reflect.Struct:
+		return t.Kind().String()
+	}
+
+	var fields []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		fields = append(fields, tag+" "+wireShape(f.Type))
+	}
+	sort.Strings(fields)
+	return "{" + strings.Join(fields, "; ") + "}"
+}
@@ -2,6 +2,7 @@
 package logging
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,12 @@ import (
 )
 
 func InitLogger(release bool, logLevel string) *zap.Logger {
+	return NewLogger(release, zap.NewAtomicLevelAt(ParseLevel(logLevel)))
+}
+
+// NewLogger builds the logger like InitLogger, logging at level, which the caller can
+// change while the logger is in use, e.g. on a configuration reload.
+func NewLogger(release bool, level zap.AtomicLevel) *zap.Logger {
 	var cfg zap.Config
 
 	if release {
@@ -30,8 +37,7 @@ func InitLogger(release bool, logLevel string) *zap.Logger {
 		logFile,
 	}
 
-	level := parseLogLevel(logLevel)
-	cfg.Level = zap.NewAtomicLevelAt(level)
+	cfg.Level = level
 
 	logger, err := cfg.Build()
 	if err != nil {
@@ -41,21 +47,13 @@ func InitLogger(release bool, logLevel string) *zap.Logger {
 	return logger
 }
 
-func parseLogLevel(level string) zapcore.Level {
-	switch level {
-	case "debug":
-		return zapcore.DebugLevel
-	case "info":
+// ParseLevel returns the named level, e.g. "warn", or info for an unknown name.
+func ParseLevel(level string) zapcore.Level {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
 		return zapcore.InfoLevel
-	case "warn":
-		return zapcore.WarnLevel
-	case "error":
-		return zapcore.ErrorLevel
-	case "fatal":
-		return zapcore.FatalLevel
-	default:
-		return zapcore.InfoLevel // default to info if invalid
 	}
+	return parsed
 }
 
 func getLogFilePath() string {
@@ -67,3 +65,19 @@ func getLogFilePath() string {
 
 	return filepath.Join(logDir, "app.log")
 }
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying logger.
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger stored by WithContext, or the global
+// logger outside of a request.
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go-web-template/internal/database"
+	"go-web-template/internal/migrate"
+	"go-web-template/migrations"
+	"go-web-template/pkg/logging"
+)
+
+const fixtureVersion = 99990101000000
+
+// GoMigrationTestSuite applies fixture Go migrations, newer than every real one, to a
+// database seeded with users whose emails need normalizing.
+type GoMigrationTestSuite struct {
+	ServiceIntegrationSuite
+	latest int64
+}
+
+func TestGoMigrationTestSuite(t *testing.T) {
+	suite.Run(t, new(GoMigrationTestSuite))
+}
+
+func (s *GoMigrationTestSuite) SetupSuite() {
+	s.ServiceIntegrationSuite.SetupSuite()
+
+	version, err := s.migrator().Version(s.Ctx)
+	s.Require().NoError(err)
+	s.latest = version
+}
+
+func (s *GoMigrationTestSuite) SetupTest() {
+	s.ServiceIntegrationSuite.SetupTest()
+
+	role, err := s.TC.Queries.GetRoleByName(s.Ctx, "user")
+	s.Require().NoError(err)
+	for i := range 25 {
+		_, err := s.TC.Queries.CreateUser(s.Ctx, database.CreateUserParams{
+			Email:       fmt.Sprintf("User%02d@Example.COM", i),
+			Password:    "hashed",
+			DisplayName: "User",
+			RoleID:      role.ID,
+		})
+		s.Require().NoError(err)
+	}
+}
+
+// TearDownTest removes the fixture's version again.
+func (s *GoMigrationTestSuite) TearDownTest() {
+	_, err := s.migrator(lowercaseEmails(0)).DownTo(s.Ctx, s.latest)
+	s.Require().NoError(err)
+}
+
+func (s *GoMigrationTestSuite) TestAppliesInBatchesWithProgress() {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := logging.WithContext(s.Ctx, zap.New(core))
+
+	results, err := s.migrator(lowercaseEmails(0)).Up(ctx)
+
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Equal(goose.TypeGo, results[0].Source.Type)
+	s.Equal(int64(fixtureVersion), results[0].Source.Version)
+	s.Equal([]string{"user00@example.com", "user24@example.com"}, s.emails(0, 24))
+
+	progress := logs.FilterMessage("migration progress").All()
+	s.Require().Len(progress, 3)
+	last := progress[2].ContextMap()
+	s.Equal(int64(25), last["done"])
+	s.Equal(int64(25), last["total"])
+	s.Equal("users", last["table"])
+	s.Equal("99990101000000_lowercase_emails", last["migration"])
+
+	version, err := s.migrator().Version(s.Ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(fixtureVersion), version)
+}
+
+func (s *GoMigrationTestSuite) TestFailureRollsBackEveryBatch() {
+	// The third batch fails after two have been written
+	_, err := s.migrator(lowercaseEmails(3)).Up(s.Ctx)
+
+	s.ErrorContains(err, "batch of users after id 20: lowercase failed")
+	s.Equal([]string{"User00@Example.COM", "User24@Example.COM"}, s.emails(0, 24))
+
+	version, err := s.migrator().Version(s.Ctx)
+	s.Require().NoError(err)
+	s.Equal(s.latest, version)
+}
+
+func (s *GoMigrationTestSuite) TestStatusListsGoMigrations() {
+	status, err := s.migrator(lowercaseEmails(0)).Status(s.Ctx)
+	s.Require().NoError(err)
+
+	s.Equal(1, status.Pending)
+	pending := status.Migrations[len(status.Migrations)-1]
+	s.Equal("99990101000000_lowercase_emails.go", pending.Name)
+	s.Equal("go", pending.Type)
+	s.Equal("pending", pending.State)
+}
+
+// lowercaseEmails reads every user through the sqlc queries, ten at a time, and
+// lowercases the email. Batch number failBatch, counting from 1, fails instead.
+func lowercaseEmails(failBatch int) *goose.Migration {
+	up := func(ctx context.Context, tx *migrations.Tx) error {
+		batch := 0
+		return tx.EachBatch(ctx, "users", 10, func(ctx context.Context, ids []int64) error {
+			if batch++; batch == failBatch {
+				return errors.New("lowercase failed")
+			}
+			for _, id := range ids {
+				user, err := tx.Queries.GetUserByID(ctx, id)
+				if err != nil {
+					return err
+				}
+				_, err = tx.ExecContext(ctx, `UPDATE users SET email = $1 WHERE id = $2`, strings.ToLower(user.Email), id)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return migrations.NewGoMigration("99990101000000_lowercase_emails.go", up, nil)
+}
+
+func (s *GoMigrationTestSuite) migrator(extra ...*goose.Migration) *migrate.Migrator {
+	migrator, err := migrate.New(s.TC.DB, extra...)
+	s.Require().NoError(err)
+	return migrator
+}
+
+// emails returns the emails of the seeded users at indexes; the truncate in SetupTest
+// restarts their ids at 1.
+func (s *GoMigrationTestSuite) emails(indexes ...int) []string {
+	emails := make([]string, len(indexes))
+	for i, index := range indexes {
+		user, err := s.TC.Queries.GetUserByID(s.Ctx, int64(index+1))
+		s.Require().NoError(err)
+		emails[i] = user.Email
+	}
+	return emails
+}
@@ -0,0 +1,38 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-web-template/internal/health"
+	"go-web-template/migrations"
+)
+
+type HealthCheckTestSuite struct {
+	ServiceIntegrationSuite
+}
+
+func TestHealthCheckTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckTestSuite))
+}
+
+func (s *HealthCheckTestSuite) TestChecksPassOnMigratedDatabase() {
+	check, err := health.Migrations(s.TC.DB, migrations.FS)
+	s.Require().NoError(err)
+
+	s.NoError(health.DBPing(s.TC.Pool)(context.Background()))
+	s.NoError(check(context.Background()))
+}
+
+func (s *HealthCheckTestSuite) TestMigrationsFailWhenBehind() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "99990101000000_future.sql"), []byte("-- +goose Up\nSELECT 1;\n"), 0o644))
+
+	check, err := health.Migrations(s.TC.DB, os.DirFS(dir))
+	s.Require().NoError(err)
+	s.ErrorContains(check(context.Background()), "expected 99990101000000")
+}
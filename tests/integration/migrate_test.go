@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+
+	"go-web-template/internal/migrate"
+)
+
+type MigratorTestSuite struct {
+	ServiceIntegrationSuite
+	migrator *migrate.Migrator
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
+
+func (s *MigratorTestSuite) SetupSuite() {
+	s.ServiceIntegrationSuite.SetupSuite()
+
+	var err error
+	s.migrator, err = migrate.New(s.TC.DB)
+	s.Require().NoError(err)
+}
+
+// TearDownTest leaves the schema complete for the next test.
+func (s *MigratorTestSuite) TearDownTest() {
+	_, err := s.migrator.Up(s.Ctx)
+	s.Require().NoError(err)
+}
+
+func (s *MigratorTestSuite) TestStatus() {
+	status, err := s.migrator.Status(s.Ctx)
+	s.Require().NoError(err)
+
+	s.Equal(status.LatestVersion, status.CurrentVersion)
+	s.Zero(status.Pending)
+	s.Require().NotEmpty(status.Migrations)
+	for _, m := range status.Migrations {
+		s.Equal("applied", m.State, m.Name)
+		s.NotNil(m.AppliedAt, m.Name)
+	}
+}
+
+func (s *MigratorTestSuite) TestDownToAndUpTo() {
+	versions := s.versions()
+	previous := versions[len(versions)-2]
+
+	results, err := s.migrator.DownTo(s.Ctx, previous)
+	s.Require().NoError(err)
+	s.Len(results, 1)
+
+	status, err := s.migrator.Status(s.Ctx)
+	s.Require().NoError(err)
+	s.Equal(previous, status.CurrentVersion)
+	s.Equal(1, status.Pending)
+
+	results, err = s.migrator.UpTo(s.Ctx, versions[len(versions)-1])
+	s.Require().NoError(err)
+	s.Len(results, 1)
+}
+
+func (s *MigratorTestSuite) TestCheck() {
+	s.NoError(s.migrator.Check(s.Ctx))
+
+	_, err := s.migrator.Down(s.Ctx)
+	s.Require().NoError(err)
+
+	versions := s.versions()
+	s.ErrorContains(s.migrator.Check(s.Ctx), "expected "+strconv.FormatInt(versions[len(versions)-1], 10))
+}
+
+func (s *MigratorTestSuite) TestRedo() {
+	results, err := s.migrator.Redo(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().Len(results, 2)
+	s.Equal("down", results[0].Direction)
+	s.Equal("up", results[1].Direction)
+	s.Equal(results[0].Source.Version, results[1].Source.Version)
+
+	version, err := s.migrator.Version(s.Ctx)
+	s.Require().NoError(err)
+	s.Equal(results[1].Source.Version, version)
+}
+
+// TestConcurrentUpAppliesOnce runs two deploys at once: the advisory lock makes the
+// second wait and find nothing left to apply.
+func (s *MigratorTestSuite) TestConcurrentUpAppliesOnce() {
+	versions := s.versions()
+	_, err := s.migrator.DownTo(s.Ctx, versions[0])
+	s.Require().NoError(err)
+
+	var wg sync.WaitGroup
+	applied := make([][]*goose.MigrationResult, 2)
+	errs := make([]error, 2)
+	for i := range 2 {
+		db := stdlib.OpenDBFromPool(s.TC.Pool)
+		defer db.Close()
+		migrator, err := migrate.New(db)
+		s.Require().NoError(err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applied[i], errs[i] = migrator.Up(s.Ctx)
+		}()
+	}
+	wg.Wait()
+
+	s.NoError(errs[0])
+	s.NoError(errs[1])
+	s.Len(append(applied[0], applied[1]...), len(versions)-1)
+}
+
+func (s *MigratorTestSuite) versions() []int64 {
+	status, err := s.migrator.Status(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().GreaterOrEqual(len(status.Migrations), 2)
+
+	versions := make([]int64, len(status.Migrations))
+	for i, m := range status.Migrations {
+		versions[i] = m.Version
+	}
+	return versions
+}
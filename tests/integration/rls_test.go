@@ -0,0 +1,281 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"go-web-template/internal/config"
+	"go-web-template/internal/database"
+	"go-web-template/internal/domains/organization"
+	"go-web-template/internal/store"
+	"go-web-template/internal/tenant"
+)
+
+// RowLevelSecurityTestSuite connects like the API does: as the container's superuser,
+// switching to the app_user role the migrations create, since superusers bypass RLS.
+type RowLevelSecurityTestSuite struct {
+	ServiceIntegrationSuite
+	appDB *pgxpool.Pool
+	txm   *store.TxManager
+	orgA  int64
+	orgB  int64
+}
+
+func TestRowLevelSecurityTestSuite(t *testing.T) {
+	suite.Run(t, new(RowLevelSecurityTestSuite))
+}
+
+func (s *RowLevelSecurityTestSuite) SetupSuite() {
+	s.ServiceIntegrationSuite.SetupSuite()
+
+	connStr, err := s.TC.Container.ConnectionString(s.Ctx, "sslmode=disable")
+	s.Require().NoError(err)
+	cfg := config.DatabaseConfig{
+		URL:              connStr,
+		AppRole:          "app_user",
+		RowLevelSecurity: true,
+		ConnectTimeout:   5 * time.Second,
+	}
+
+	s.appDB, err = store.NewPool(s.Ctx, cfg, zap.NewNop())
+	s.Require().NoError(err)
+	s.txm, err = store.NewTxManager(s.appDB, cfg)
+	s.Require().NoError(err)
+}
+
+func (s *RowLevelSecurityTestSuite) TearDownSuite() {
+	if s.appDB != nil {
+		s.appDB.Close()
+	}
+	s.ServiceIntegrationSuite.TearDownSuite()
+}
+
+func (s *RowLevelSecurityTestSuite) SetupTest() {
+	s.ServiceIntegrationSuite.SetupTest()
+	s.orgA = s.seedOrganization("org-a", "alice@example.com")
+	s.orgB = s.seedOrganization("org-b", "bob@example.com")
+}
+
+func (s *RowLevelSecurityTestSuite) TestCrossTenantReadsFail() {
+	ctx := tenant.WithOrganizationID(context.Background(), s.orgA)
+
+	err := s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		// Queries that target another tenant explicitly see nothing
+		members, err := q.ListOrganizationMembers(ctx, s.orgB)
+		s.Require().NoError(err)
+		s.Assert().Empty(members)
+
+		_, err = q.GetOrganizationByID(ctx, s.orgB)
+		s.Assert().ErrorIs(err, pgx.ErrNoRows)
+
+		own, err := q.ListOrganizationMembers(ctx, s.orgA)
+		s.Require().NoError(err)
+		s.Assert().Len(own, 1)
+		return nil
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RowLevelSecurityTestSuite) TestUnfilteredQueryOnlySeesTenant() {
+	tx, err := s.appDB.Begin(context.Background())
+	s.Require().NoError(err)
+	defer func() {
+		_ = tx.Rollback(context.Background())
+	}()
+
+	s.Require().NoError(store.SetTenant(context.Background(), tx, s.orgA))
+
+	// A query that forgot its WHERE organization_id = $1
+	var count int
+	s.Require().NoError(tx.QueryRow(context.Background(), `SELECT COUNT(*) FROM organization_members`).Scan(&count))
+	s.Assert().Equal(1, count)
+}
+
+func (s *RowLevelSecurityTestSuite) TestCrossTenantWritesFail() {
+	ctx := tenant.WithOrganizationID(context.Background(), s.orgA)
+	role, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+	intruder := s.seedUser("mallory@example.com")
+
+	err = s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		_, err := q.AddOrganizationMember(ctx, database.AddOrganizationMemberParams{
+			OrganizationID: s.orgB,
+			UserID:         intruder,
+			RoleID:         role.ID,
+		})
+		return err
+	})
+	s.Require().Error(err)
+
+	affected := int64(0)
+	err = s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		var err error
+		affected, err = q.RemoveOrganizationMember(ctx, database.RemoveOrganizationMemberParams{
+			OrganizationID: s.orgB,
+			UserID:         s.ownerOf(s.orgB),
+		})
+		return err
+	})
+	s.Require().NoError(err)
+	s.Assert().Zero(affected)
+}
+
+func (s *RowLevelSecurityTestSuite) TestNestedWithTxSwitchesTenantForItsSavepoint() {
+	ctx := tenant.WithOrganizationID(context.Background(), s.orgA)
+
+	err := s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		err := s.txm.WithTx(tenant.WithOrganizationID(ctx, s.orgB), func(ctx context.Context, q database.Querier) error {
+			_, err := q.GetOrganizationByID(ctx, s.orgB)
+			s.Assert().NoError(err)
+			_, err = q.GetOrganizationByID(ctx, s.orgA)
+			s.Assert().ErrorIs(err, pgx.ErrNoRows)
+			return nil
+		})
+		s.Require().NoError(err)
+
+		// The outer tenant is back once the nested call returns
+		_, err = q.GetOrganizationByID(ctx, s.orgA)
+		s.Assert().NoError(err)
+		_, err = q.GetOrganizationByID(ctx, s.orgB)
+		s.Assert().ErrorIs(err, pgx.ErrNoRows)
+		return nil
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RowLevelSecurityTestSuite) TestTxWithoutTenantSeesNothing() {
+	err := s.txm.WithTx(context.Background(), func(ctx context.Context, q database.Querier) error {
+		members, err := q.ListOrganizationMembers(context.Background(), s.orgB)
+		s.Assert().Empty(members)
+		return err
+	})
+	s.Require().NoError(err)
+
+	// Nor does a query outside any transaction
+	var count int
+	s.Require().NoError(s.appDB.QueryRow(context.Background(), `SELECT COUNT(*) FROM organizations`).Scan(&count))
+	s.Assert().Zero(count)
+
+	// And rows can't be written for a tenant that isn't set
+	_, err = database.New(s.appDB).CreateOrganization(context.Background(), database.CreateOrganizationParams{
+		Name: "Stray",
+		Slug: "stray",
+	})
+	s.Assert().Error(err)
+}
+
+func (s *RowLevelSecurityTestSuite) TestBypassSeesEveryTenant() {
+	ctx := tenant.WithBypass(tenant.WithOrganizationID(context.Background(), s.orgA))
+
+	err := s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		members, err := q.ListOrganizationMembers(ctx, s.orgB)
+		s.Assert().Len(members, 1)
+		return err
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RowLevelSecurityTestSuite) TestInvitationsAreIsolated() {
+	role, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+	for _, organizationID := range []*int64{nil, &s.orgB} {
+		_, err := s.TC.Queries.CreateInvitation(context.Background(), database.CreateInvitationParams{
+			Email:          "invitee@example.com",
+			RoleID:         role.ID,
+			ExpiresAt:      time.Now().Add(time.Hour),
+			OrganizationID: organizationID,
+		})
+		s.Require().NoError(err)
+	}
+
+	ctx := tenant.WithOrganizationID(context.Background(), s.orgA)
+	err = s.txm.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		invitations, err := q.ListOrganizationInvitations(ctx, s.orgB)
+		s.Require().NoError(err)
+		s.Assert().Empty(invitations)
+
+		// Global invitations belong to no tenant
+		global, err := q.ListInvitations(ctx)
+		s.Assert().Len(global, 1)
+		return err
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RowLevelSecurityTestSuite) TestServicesScopeTheirTransactions() {
+	svc := organization.NewOrganizationService(database.New(s.appDB), s.txm, s.TC.Services.AuditService)
+	owner := s.seedUser("carol@example.com")
+
+	org, err := svc.CreateOrganization(context.Background(), owner, "Org C", "org-c")
+	s.Require().NoError(err)
+
+	orgs, err := svc.ListUserOrganizations(context.Background(), owner)
+	s.Require().NoError(err)
+	s.Assert().Len(orgs, 1)
+
+	isMember, err := svc.IsMember(context.Background(), org.ID, owner)
+	s.Require().NoError(err)
+	s.Assert().True(isMember)
+
+	allowed, err := svc.HasOrganizationPermission(context.Background(), org.ID, owner, "users:write")
+	s.Require().NoError(err)
+	s.Assert().True(allowed)
+
+	members, err := svc.ListMembers(tenant.WithOrganizationID(context.Background(), org.ID))
+	s.Require().NoError(err)
+	s.Assert().Len(members, 1)
+}
+
+func (s *RowLevelSecurityTestSuite) TestCheckRowLevelSecurity() {
+	s.Assert().NoError(store.CheckRowLevelSecurity(context.Background(), s.appDB))
+
+	// The container's own user is a superuser
+	err := store.CheckRowLevelSecurity(context.Background(), s.TC.Pool)
+	s.Assert().ErrorIs(err, store.ErrRowLevelSecurityBypassed)
+}
+
+func (s *RowLevelSecurityTestSuite) seedOrganization(slug, ownerEmail string) int64 {
+	org, err := s.TC.Queries.CreateOrganization(context.Background(), database.CreateOrganizationParams{
+		Name: slug,
+		Slug: slug,
+	})
+	s.Require().NoError(err)
+
+	role, err := s.TC.Queries.GetRoleByName(context.Background(), "admin")
+	s.Require().NoError(err)
+
+	_, err = s.TC.Queries.AddOrganizationMember(context.Background(), database.AddOrganizationMemberParams{
+		OrganizationID: org.ID,
+		UserID:         s.seedUser(ownerEmail),
+		RoleID:         role.ID,
+	})
+	s.Require().NoError(err)
+	return org.ID
+}
+
+func (s *RowLevelSecurityTestSuite) seedUser(email string) int64 {
+	role, err := s.TC.Queries.GetRoleByName(context.Background(), "user")
+	s.Require().NoError(err)
+
+	u, err := s.TC.Queries.CreateUser(context.Background(), database.CreateUserParams{
+		Email:       email,
+		Password:    "hashed",
+		DisplayName: email,
+		RoleID:      role.ID,
+	})
+	s.Require().NoError(err)
+	return u.ID
+}
+
+func (s *RowLevelSecurityTestSuite) ownerOf(organizationID int64) int64 {
+	members, err := s.TC.Queries.ListOrganizationMembers(context.Background(), organizationID)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(members)
+	return members[0].UserID
+}
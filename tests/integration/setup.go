@@ -3,12 +3,16 @@ package integration
 import (
 	"context"
 	"database/sql"
+	"go-web-template/internal/domains/audit"
+	"go-web-template/internal/domains/auth"
+	"go-web-template/internal/domains/invitation"
+	"go-web-template/internal/domains/organization"
 	"go-web-template/internal/domains/user"
-	"path/filepath"
+	"go-web-template/internal/migrate"
 	"time"
 
-	_ "github.com/lib/pq"
-	"github.com/pressly/goose/v3"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -17,18 +21,26 @@ import (
 
 	"go-web-template/internal/config"
 	"go-web-template/internal/database"
+	"go-web-template/internal/store"
 	"go-web-template/internal/store/seeders"
 )
 
 type TestContainer struct {
 	Container *postgres.PostgresContainer
+	Pool      *pgxpool.Pool
+	// DB is a database/sql handle on Pool, for goose
 	DB        *sql.DB
 	Queries   *database.Queries
+	TxManager *store.TxManager
 	Services  *Services
 }
 
 type Services struct {
-	UserService *user.UserService
+	UserService         *user.UserService
+	OrganizationService *organization.OrganizationService
+	AuditService        *audit.AuditService
+	AuthService         *auth.AuthService
+	InvitationService   *invitation.InvitationService
 	// Add more services here
 }
 
@@ -41,7 +53,46 @@ type ServiceIntegrationSuite struct {
 func (s *ServiceIntegrationSuite) SetupSuite() {
 	s.Ctx = context.Background()
 
-	// Start PostgreSQL container
+	container, pool := s.StartPostgres()
+	db := stdlib.OpenDBFromPool(pool)
+
+	// Create queries
+	queries := database.New(pool)
+
+	// Seed basic data (roles, permissions)
+	logger := zap.NewNop()
+	cfg := &config.Config{} // empty config for tests
+	err := seeders.SeedDatabase(s.Ctx, pool, logger, cfg, "core")
+	s.Require().NoError(err, "seeding failed")
+
+	// Initialize services
+	userService := user.NewUserService(queries)
+	txManager, err := store.NewTxManager(pool, config.DatabaseConfig{RowLevelSecurity: true, TxMaxRetries: 3})
+	s.Require().NoError(err)
+	auditService := audit.NewAuditService(queries)
+	organizationService := organization.NewOrganizationService(queries, txManager, auditService)
+	authService := auth.NewAuthService(queries, txManager, auditService)
+	invitationService := invitation.NewInvitationService(queries, txManager, authService, auditService, "test-invite-secret", time.Hour)
+
+	s.TC = &TestContainer{
+		Container: container,
+		Pool:      pool,
+		DB:        db,
+		Queries:   queries,
+		TxManager: txManager,
+		Services: &Services{
+			UserService:         userService,
+			OrganizationService: organizationService,
+			AuditService:        auditService,
+			AuthService:         authService,
+			InvitationService:   invitationService,
+		},
+	}
+}
+
+// StartPostgres starts a PostgreSQL container with the migrations applied. Suites that
+// need a second database, e.g. as a replica, terminate it themselves.
+func (s *ServiceIntegrationSuite) StartPostgres() (*postgres.PostgresContainer, *pgxpool.Pool) {
 	container, err := postgres.Run(s.Ctx,
 		"postgres:16-alpine",
 		postgres.WithDatabase("testdb"),
@@ -55,53 +106,35 @@ func (s *ServiceIntegrationSuite) SetupSuite() {
 	)
 	s.Require().NoError(err, "failed to start container")
 
-	// Connect to container
 	connStr, err := container.ConnectionString(s.Ctx, "sslmode=disable")
 	s.Require().NoError(err, "failed to get connection string")
 
-	db, err := sql.Open("postgres", connStr)
+	pool, err := pgxpool.New(s.Ctx, connStr)
 	s.Require().NoError(err, "failed to connect to database")
 
-	// Run migrations
-	err = goose.SetDialect("postgres")
-	s.Require().NoError(err, "failed to set goose dialect")
-
-	migrationsPath := filepath.Join("..", "..", "..", "migrations")
-	err = goose.Up(db, migrationsPath)
+	db := stdlib.OpenDBFromPool(pool)
+	defer db.Close()
+	migrator, err := migrate.New(db)
+	s.Require().NoError(err, "failed to load migrations")
+	_, err = migrator.Up(s.Ctx)
 	s.Require().NoError(err, "migrations failed")
 
-	// Create queries
-	queries := database.New(db)
-
-	// Seed basic data (roles, permissions)
-	logger := zap.NewNop()
-	cfg := &config.Config{} // empty config for tests
-	err = seeders.SeedDatabase(s.Ctx, db, logger, cfg, "core")
-	s.Require().NoError(err, "seeding failed")
-
-	// Initialize services
-	userService := user.NewUserService(queries)
-
-	s.TC = &TestContainer{
-		Container: container,
-		DB:        db,
-		Queries:   queries,
-		Services: &Services{
-			UserService: userService,
-		},
-	}
+	return container, pool
 }
 
 func (s *ServiceIntegrationSuite) SetupTest() {
-	// Truncate users between tests (keep roles)
-	_, err := s.TC.DB.Exec(`TRUNCATE TABLE users RESTART IDENTITY CASCADE`)
-	s.Require().NoError(err, "failed to truncate users table")
+	// Truncate users and organizations between tests (keep roles)
+	_, err := s.TC.Pool.Exec(s.Ctx, `TRUNCATE TABLE users, organizations, audit_events RESTART IDENTITY CASCADE`)
+	s.Require().NoError(err, "failed to truncate users and organizations tables")
 }
 
 func (s *ServiceIntegrationSuite) TearDownSuite() {
 	if s.TC.DB != nil {
 		_ = s.TC.DB.Close()
 	}
+	if s.TC.Pool != nil {
+		s.TC.Pool.Close()
+	}
 
 	if s.TC.Container != nil {
 		if err := s.TC.Container.Terminate(s.Ctx); err != nil {
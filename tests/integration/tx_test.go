@@ -0,0 +1,151 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+
+	"go-web-template/internal/database"
+	"go-web-template/internal/store"
+)
+
+type TxManagerTestSuite struct {
+	ServiceIntegrationSuite
+}
+
+func TestTxManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(TxManagerTestSuite))
+}
+
+func (s *TxManagerTestSuite) TestRollbackOnError() {
+	errBoom := errors.New("boom")
+
+	err := s.TC.TxManager.WithTx(context.Background(), func(ctx context.Context, q database.Querier) error {
+		s.createOrganization(q, "rolled-back")
+		return errBoom
+	})
+	s.Require().ErrorIs(err, errBoom)
+
+	_, err = s.TC.Queries.GetOrganizationBySlug(context.Background(), "rolled-back")
+	s.Assert().ErrorIs(err, pgx.ErrNoRows)
+}
+
+func (s *TxManagerTestSuite) TestSavepointRollsBackOnlyInnerWork() {
+	ctx := context.Background()
+
+	err := s.TC.TxManager.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		s.createOrganization(q, "outer")
+
+		innerErr := store.Savepoint(ctx, q, func(ctx context.Context, q database.Querier) error {
+			s.createOrganization(q, "inner")
+			return errors.New("inner failed")
+		})
+		s.Require().Error(innerErr)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	_, err = s.TC.Queries.GetOrganizationBySlug(ctx, "outer")
+	s.Assert().NoError(err)
+	_, err = s.TC.Queries.GetOrganizationBySlug(ctx, "inner")
+	s.Assert().ErrorIs(err, pgx.ErrNoRows)
+}
+
+func (s *TxManagerTestSuite) TestNestedWithTxJoinsOuterTransaction() {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	err := s.TC.TxManager.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		err := s.TC.TxManager.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+			s.createOrganization(q, "inner")
+			return nil
+		})
+		s.Require().NoError(err)
+		return errBoom
+	})
+	s.Require().ErrorIs(err, errBoom)
+
+	// The inner call committed nothing on its own
+	_, err = s.TC.Queries.GetOrganizationBySlug(ctx, "inner")
+	s.Assert().ErrorIs(err, pgx.ErrNoRows)
+}
+
+func (s *TxManagerTestSuite) TestNestedWithTxFailureRollsBackOnlyInnerWork() {
+	ctx := context.Background()
+
+	err := s.TC.TxManager.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+		s.createOrganization(q, "outer")
+
+		innerErr := s.TC.TxManager.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+			s.createOrganization(q, "inner")
+			return errors.New("inner failed")
+		})
+		s.Require().Error(innerErr)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	_, err = s.TC.Queries.GetOrganizationBySlug(ctx, "outer")
+	s.Assert().NoError(err)
+	_, err = s.TC.Queries.GetOrganizationBySlug(ctx, "inner")
+	s.Assert().ErrorIs(err, pgx.ErrNoRows)
+}
+
+func (s *TxManagerTestSuite) TestOnlyOutermostWithTxRetries() {
+	outer, inner := 0, 0
+
+	err := s.TC.TxManager.WithTx(context.Background(), func(ctx context.Context, q database.Querier) error {
+		outer++
+		return s.TC.TxManager.WithTx(ctx, func(ctx context.Context, q database.Querier) error {
+			inner++
+			if outer < 2 {
+				return &pgconn.PgError{Code: "40001"}
+			}
+			return nil
+		})
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(2, outer)
+	s.Assert().Equal(2, inner)
+}
+
+func (s *TxManagerTestSuite) TestRetriesSerializationFailures() {
+	attempts := 0
+
+	err := s.TC.TxManager.WithTxOptions(context.Background(), store.TxOptions{Isolation: pgx.Serializable}, func(ctx context.Context, q database.Querier) error {
+		attempts++
+		if attempts < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		s.createOrganization(q, "retried")
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(3, attempts)
+
+	_, err = s.TC.Queries.GetOrganizationBySlug(context.Background(), "retried")
+	s.Assert().NoError(err)
+}
+
+func (s *TxManagerTestSuite) TestDoesNotRetryOtherErrors() {
+	attempts := 0
+
+	err := s.TC.TxManager.WithTx(context.Background(), func(ctx context.Context, q database.Querier) error {
+		attempts++
+		return &pgconn.PgError{Code: "23505"}
+	})
+	s.Require().Error(err)
+	s.Assert().Equal(1, attempts)
+}
+
+func (s *TxManagerTestSuite) createOrganization(q database.Querier, slug string) {
+	_, err := q.CreateOrganization(context.Background(), database.CreateOrganizationParams{
+		Name: slug,
+		Slug: slug,
+	})
+	s.Require().NoError(err)
+}